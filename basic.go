@@ -296,15 +296,17 @@ func IsUpperCaseWord(word string) bool {
 	return true
 }
 
-// ToTitleCase returns the input string in Title Case
+// ToTitleCase returns an all-caps name in its conventional
+// capitalization (see NameCase) and leaves other input as it is. It is
+// meant for names: surname particles are lower-cased unless they end the
+// phrase, so general text such as "LA FITNESS" becomes "la Fitness",
+// where strings.Title gave "La Fitness".
 func ToTitleCase(ctx context.Context, phrase string) string {
 	if IsUpperCaseWord(phrase) {
 		//The entire name is in uppercase.
-		//Convert it to title case. This requires first setting all characters
-		//to lower case, and then changing it to Title
-		phrase = strings.ToLower(phrase)
-		phrase = strings.Title(phrase)
-		return phrase
+		//Convert it with NameCase, which handles McDonald, O'Brien,
+		//van der Berg, III, and the like
+		return NameCase(phrase)
 	}
 	return phrase
 }
@@ -433,23 +435,19 @@ func Int64ToCSV(vals []int64) string {
 	return strings.Join(IDs, ", ")
 }
 
-// SplitSubN splits a string by length
+// SplitSubN splits a string by length, every *numberOfCharactersPerLine*
+// runes even mid-word; see Wrap to break at words
 func SplitSubN(s string, numberOfCharactersPerLine int) []string {
 	n := numberOfCharactersPerLine
 
-	sub := ""
 	subs := []string{}
 
 	runes := bytes.Runes([]byte(s))
-	l := len(runes)
-	for i, r := range runes {
-		sub = sub + string(r)
-		if (i+1)%n == 0 {
-			subs = append(subs, sub)
-			sub = ""
-		} else if (i + 1) == l {
-			subs = append(subs, sub)
-		}
+	if n <= 0 {
+		n = max(len(runes), 1)
+	}
+	for start := 0; start < len(runes); start += n {
+		subs = append(subs, string(runes[start:min(start+n, len(runes))]))
 	}
 
 	return subs
@@ -0,0 +1,379 @@
+/*
+Command nameparse splits a column of names in a CSV or TSV file into its
+parts with utils.ParseName, appending salutation, first_name, middle_name,
+last_name, generation, suffix and nickname columns to each row. It reads
+from the named file or standard input and writes to standard output one
+row at a time, so files of any size run in constant memory.
+
+Usage:
+
+	nameparse [flags] [file]
+
+	nameparse -column "Owner Name" owners.csv > split.csv
+	nameparse -column 3 -no-header -tsv < owners.tsv
+	nameparse -column owner -case name -corporate skip -jsonl owners.csv
+
+The column is a header name or a 1-based index. Case is keep (ParseName's
+default, which name-cases all-caps and all-lower input), name, upper or
+lower. Corporate rows (organizations and trusts) are kept with blank name
+columns, skipped, or kept with the whole value in last_name. A joint name
+("John and Jane Doe") fills the name columns with its first person, or
+with -couples rows, writes the row once per person.
+
+The added columns must not share a name with an input column; use -prefix
+to rename them. Short rows are padded to the header, or without a header
+to the name column, before the added columns. JSON Lines objects keep the
+input's column order, followed by the added columns; repeated header
+names get a numeric suffix (name, name_2) so every key is unique.
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bjbigler/utils"
+)
+
+// nameColumns are appended to every row, in this order
+var nameColumns = []string{"salutation", "first_name", "middle_name", "last_name", "generation", "suffix", "nickname"}
+
+type options struct {
+	column    string
+	tsv       bool
+	noHeader  bool
+	nameCase  string
+	corporate string
+	jsonLines bool
+	prefix    string
+	couples   string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.column, "column", "", "name column: header name or 1-based index (required)")
+	flag.BoolVar(&opts.tsv, "tsv", false, "tab-separated input and output (default for .tsv files)")
+	flag.BoolVar(&opts.noHeader, "no-header", false, "input has no header row; -column must be an index")
+	flag.StringVar(&opts.nameCase, "case", "keep", "case of the name columns: keep, name, upper or lower")
+	flag.StringVar(&opts.corporate, "corporate", "keep", "organization and trust rows: keep, skip or last")
+	flag.BoolVar(&opts.jsonLines, "jsonl", false, "write JSON Lines instead of delimited rows")
+	flag.StringVar(&opts.prefix, "prefix", "", "prefix for the added column names")
+	flag.StringVar(&opts.couples, "couples", "first", "joint names: first (the first person only) or rows (one row per person)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: nameparse [flags] [file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(opts, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "nameparse: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options, args []string) error {
+	switch {
+	case opts.column == "":
+		return fmt.Errorf("-column is required")
+	case len(args) > 1:
+		return fmt.Errorf("expected at most one input file, got %d", len(args))
+	}
+	if err := validateChoice("case", opts.nameCase, "keep", "name", "upper", "lower"); err != nil {
+		return err
+	}
+	if err := validateChoice("corporate", opts.corporate, "keep", "skip", "last"); err != nil {
+		return err
+	}
+	if err := validateChoice("couples", opts.couples, "first", "rows"); err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+		opts.tsv = opts.tsv || strings.HasSuffix(strings.ToLower(args[0]), ".tsv")
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	if err := split(opts, bufio.NewReader(in), out); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// split streams rows from in to out, appending the name columns
+func split(opts options, in io.Reader, out io.Writer) error {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	if opts.tsv {
+		reader.Comma = '\t'
+	}
+
+	var header []string
+	if !opts.noHeader {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		header = append([]string{}, record...)
+	}
+
+	column, err := columnIndex(opts.column, header, opts.noHeader)
+	if err != nil {
+		return err
+	}
+
+	added := make([]string, len(nameColumns))
+	for i, name := range nameColumns {
+		added[i] = opts.prefix + name
+	}
+
+	w, err := newRowWriter(opts, out, header, added, column)
+	if err != nil {
+		return err
+	}
+	if header != nil {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var value string
+		if column < len(record) {
+			value = record[column]
+		}
+
+		for _, parts := range splitName(opts, value) {
+			if err := w.write(record, parts); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.flush()
+}
+
+// splitName returns the name columns for value: one set per output row,
+// or none if the row should be skipped
+func splitName(opts options, value string) [][]string {
+	parsed := utils.ParseNameEntity(value)
+	people := parsed.People
+
+	switch parsed.Entity {
+	case utils.EntityOrganization, utils.EntityTrustEstate:
+		switch opts.corporate {
+		case "skip":
+			return nil
+		case "last":
+			people = []utils.NameParts{{LastName: strings.TrimSpace(value)}}
+		}
+	case utils.EntityCouple:
+		if opts.couples == "first" {
+			people = people[:1]
+		}
+	}
+	if len(people) == 0 {
+		people = []utils.NameParts{{}}
+	}
+
+	rows := make([][]string, 0, len(people))
+	for _, p := range people {
+		parts := []string{p.Salutation, p.FirstName, p.MiddleName, p.LastName, p.Generation, p.Suffix, p.Nickname}
+		for i, part := range parts {
+			switch opts.nameCase {
+			case "name":
+				parts[i] = utils.NameCase(part)
+			case "upper":
+				parts[i] = strings.ToUpper(part)
+			case "lower":
+				parts[i] = strings.ToLower(part)
+			}
+		}
+		rows = append(rows, parts)
+	}
+	return rows
+}
+
+// columnIndex resolves a header name or 1-based index to a 0-based index
+func columnIndex(column string, header []string, noHeader bool) (int, error) {
+	if n, err := strconv.Atoi(column); err == nil {
+		if n < 1 {
+			return 0, fmt.Errorf("column index %d must be 1 or more", n)
+		}
+		return n - 1, nil
+	}
+	if noHeader {
+		return 0, fmt.Errorf(`column "%s" must be an index when there is no header`, column)
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf(`no column named "%s" in header`, column)
+}
+
+func validateChoice(flagName, value string, choices ...string) error {
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("-%s must be one of %s, not %q", flagName, strings.Join(choices, ", "), value)
+}
+
+// rowWriter writes rows as delimited text or JSON Lines
+type rowWriter struct {
+	header []string
+	added  []string
+	width  int // input columns every row is padded to
+	csv    *csv.Writer
+	out    io.Writer
+	buf    bytes.Buffer
+	json   *json.Encoder   // writes JSON values into buf
+	keys   []string        // JSON keys of the input columns seen so far
+	used   map[string]bool // every key in keys and added
+}
+
+// newRowWriter returns an error if an added column has the name of an
+// input column, which would give the output two columns of that name.
+// *column* is the name column's index.
+func newRowWriter(opts options, out io.Writer, header, added []string, column int) (*rowWriter, error) {
+	for _, name := range added {
+		for _, existing := range header {
+			if strings.EqualFold(strings.TrimSpace(existing), name) {
+				return nil, fmt.Errorf(`input already has a "%s" column; use -prefix to rename the added columns`, existing)
+			}
+		}
+	}
+
+	w := &rowWriter{header: header, added: added, width: max(len(header), column+1)}
+	if opts.jsonLines {
+		w.out = out
+		w.json = json.NewEncoder(&w.buf)
+		w.json.SetEscapeHTML(false)
+		w.used = make(map[string]bool)
+		for _, name := range added {
+			w.used[name] = true
+		}
+		return w, nil
+	}
+
+	w.csv = csv.NewWriter(out)
+	if opts.tsv {
+		w.csv.Comma = '\t'
+	}
+	return w, nil
+}
+
+func (w *rowWriter) writeHeader() error {
+	if w.csv == nil {
+		return nil
+	}
+	return w.csv.Write(append(append([]string{}, w.header...), w.added...))
+}
+
+func (w *rowWriter) write(record, parts []string) error {
+	if w.csv != nil {
+		// Pad short rows so the name columns line up under their headers
+		row := append([]string{}, record...)
+		for len(row) < w.width {
+			row = append(row, "")
+		}
+		return w.csv.Write(append(row, parts...))
+	}
+
+	// A map would sort the keys, so the object is written field by field
+	w.buf.Reset()
+	w.buf.WriteByte('{')
+	for i, value := range record {
+		if err := w.field(i, w.key(i), value); err != nil {
+			return err
+		}
+	}
+	for i, value := range parts {
+		if err := w.field(len(record)+i, w.added[i], value); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteString("}\n")
+
+	_, err := w.out.Write(w.buf.Bytes())
+	return err
+}
+
+// key returns the JSON key of input column *i*: its header name, or
+// column_1, column_2, ... without one, made unique with a _2, _3 suffix
+func (w *rowWriter) key(i int) string {
+	for len(w.keys) <= i {
+		n := len(w.keys)
+		base := fmt.Sprintf("column_%d", n+1)
+		if n < len(w.header) && w.header[n] != "" {
+			base = w.header[n]
+		}
+
+		key := base
+		for suffix := 2; w.used[key]; suffix++ {
+			key = fmt.Sprintf("%s_%d", base, suffix)
+		}
+		w.used[key] = true
+		w.keys = append(w.keys, key)
+	}
+	return w.keys[i]
+}
+
+// field appends the n'th "key":"value" pair of a JSON object to buf
+func (w *rowWriter) field(n int, key, value string) error {
+	if n > 0 {
+		w.buf.WriteByte(',')
+	}
+	if err := w.json.Encode(key); err != nil {
+		return err
+	}
+	// Encode ends each value with a newline
+	w.buf.Truncate(w.buf.Len() - 1)
+	w.buf.WriteByte(':')
+	if err := w.json.Encode(value); err != nil {
+		return err
+	}
+	w.buf.Truncate(w.buf.Len() - 1)
+	return nil
+}
+
+func (w *rowWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
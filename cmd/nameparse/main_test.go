@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	defaults := options{nameCase: "keep", corporate: "keep", couples: "first"}
+	with := func(change func(*options)) options {
+		opts := defaults
+		change(&opts)
+		return opts
+	}
+
+	tests := []struct {
+		name string
+		opts options
+		in   string
+		want string
+	}{
+		{
+			"header",
+			with(func(o *options) { o.column = "owner" }),
+			"id,Owner,city\n1,Mr. John Q. Smith Jr.,Akron\n2,Jane Doe\n",
+			"id,Owner,city,salutation,first_name,middle_name,last_name,generation,suffix,nickname\n" +
+				"1,Mr. John Q. Smith Jr.,Akron,Mr.,John,Q.,Smith,Jr.,,\n" +
+				"2,Jane Doe,,,Jane,,Doe,,,\n",
+		},
+		{
+			"no header, short rows padded to the name column",
+			with(func(o *options) { o.column = "3"; o.noHeader = true }),
+			"1,Akron,John Smith\n2\n",
+			"1,Akron,John Smith,,John,,Smith,,,\n" +
+				"2,,,,,,,,,\n",
+		},
+		{
+			"tsv",
+			with(func(o *options) { o.column = "owner"; o.tsv = true; o.nameCase = "upper" }),
+			"id\towner\n1\tJohn Smith\n",
+			"id\towner\tsalutation\tfirst_name\tmiddle_name\tlast_name\tgeneration\tsuffix\tnickname\n" +
+				"1\tJohn Smith\t\tJOHN\t\tSMITH\t\t\t\n",
+		},
+		{
+			"couples first",
+			with(func(o *options) { o.column = "owner" }),
+			"id,owner\n1,John and Jane Doe\n",
+			"id,owner,salutation,first_name,middle_name,last_name,generation,suffix,nickname\n" +
+				"1,John and Jane Doe,,John,,Doe,,,\n",
+		},
+		{
+			"couples rows",
+			with(func(o *options) { o.column = "owner"; o.couples = "rows" }),
+			"id,owner\n1,John and Jane Doe\n",
+			"id,owner,salutation,first_name,middle_name,last_name,generation,suffix,nickname\n" +
+				"1,John and Jane Doe,,John,,Doe,,,\n" +
+				"1,John and Jane Doe,,Jane,,Doe,,,\n",
+		},
+		{
+			"corporate skip",
+			with(func(o *options) { o.column = "owner"; o.corporate = "skip" }),
+			"id,owner\n1,Acme Widgets Inc.\n2,John Smith\n",
+			"id,owner,salutation,first_name,middle_name,last_name,generation,suffix,nickname\n" +
+				"2,John Smith,,John,,Smith,,,\n",
+		},
+		{
+			"corporate last",
+			with(func(o *options) { o.column = "owner"; o.corporate = "last" }),
+			"id,owner\n1,Acme Widgets Inc.\n",
+			"id,owner,salutation,first_name,middle_name,last_name,generation,suffix,nickname\n" +
+				"1,Acme Widgets Inc.,,,,Acme Widgets Inc.,,,\n",
+		},
+		{
+			"jsonl without header",
+			with(func(o *options) { o.column = "1"; o.noHeader = true; o.jsonLines = true; o.prefix = "n_" }),
+			"John Smith,Akron\n",
+			`{"column_1":"John Smith","column_2":"Akron","n_salutation":"","n_first_name":"John","n_middle_name":"","n_last_name":"Smith","n_generation":"","n_suffix":"","n_nickname":""}` + "\n",
+		},
+		{
+			"jsonl keys are unique",
+			with(func(o *options) { o.column = "1"; o.jsonLines = true; o.prefix = "n_" }),
+			"column_3,name,name\nJohn Smith,x,y,z\n",
+			`{"column_3":"John Smith","name":"x","name_2":"y","column_4":"z","n_salutation":"","n_first_name":"John","n_middle_name":"","n_last_name":"Smith","n_generation":"","n_suffix":"","n_nickname":""}` + "\n",
+		},
+		{
+			"jsonl generated key after a header of that name",
+			with(func(o *options) { o.column = "1"; o.jsonLines = true; o.prefix = "n_" }),
+			"name,column_3\nJohn Smith,x,y\n",
+			`{"name":"John Smith","column_3":"x","column_3_2":"y","n_salutation":"","n_first_name":"John","n_middle_name":"","n_last_name":"Smith","n_generation":"","n_suffix":"","n_nickname":""}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if err := split(tt.opts, strings.NewReader(tt.in), &out); err != nil {
+			t.Errorf("%s: split() error = %v", tt.name, err)
+			continue
+		}
+		if got := out.String(); got != tt.want {
+			t.Errorf("%s: split() =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts options
+		in   string
+	}{
+		{"unknown column", options{column: "owner"}, "id,name\n"},
+		{"named column without header", options{column: "owner", noHeader: true}, "1,John Smith\n"},
+		{"added column already in input", options{column: "owner"}, "owner,last_name\n"},
+	}
+	for _, tt := range tests {
+		if err := split(tt.opts, strings.NewReader(tt.in), &bytes.Buffer{}); err == nil {
+			t.Errorf("%s: split() error = nil, want an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Package cron parses cron expressions, computes their next and previous
+// run times in a given location, and runs jobs on them in-process.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// macros maps the @-style shortcuts to their five-field equivalents
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// bounds describes the legal values of a field
+type bounds struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	secondBounds = bounds{"second", 0, 59, nil}
+	minuteBounds = bounds{"minute", 0, 59, nil}
+	hourBounds   = bounds{"hour", 0, 23, nil}
+	domBounds    = bounds{"day of month", 1, 31, nil}
+	monthBounds  = bounds{"month", 1, 12, monthNames}
+	dowBounds    = bounds{"day of week", 0, 7, dayNames}
+)
+
+// Parse parses a cron expression for evaluation in *loc*. It accepts
+// the standard five fields (minute hour day-of-month month day-of-week),
+// six fields with a leading seconds field, and the macros @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+//
+// Each field takes *, ?, single values, ranges (1-5), lists (1,3,5) and
+// steps (*/15, 10-40/10, 5/20). Months and weekdays accept names (JAN,
+// MON). In addition, day-of-month accepts L (last day), L-n (n days
+// before the last), nW (weekday nearest the nth) and LW (last weekday),
+// and day-of-week accepts dL (last d of the month, e.g. 5L or FRIL) and
+// d#n (the nth d of the month, e.g. MON#2).
+func Parse(spec string, loc *time.Location) (*Schedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "@") {
+		expanded, ok := macros[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf(`unrecognized cron macro "%s"`, expr)
+		}
+		expr = expanded
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf(`cron expression "%s" must have 5 or 6 fields, has %d`, spec, len(fields))
+	}
+
+	s := &Schedule{spec: spec, loc: loc}
+	var err error
+
+	if s.second, err = parseField(fields[0], secondBounds); err != nil {
+		return nil, err
+	}
+	if s.minute, err = parseField(fields[1], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[2], hourBounds); err != nil {
+		return nil, err
+	}
+	if err = s.parseDayOfMonth(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[4], monthBounds); err != nil {
+		return nil, err
+	}
+	if err = s.parseDayOfWeek(fields[5]); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// allDays and allWeekdays are the day-of-month and day-of-week
+// bitsets that match every day
+const (
+	allDays     uint64 = (1<<32 - 1) &^ 1
+	allWeekdays uint64 = 1<<7 - 1
+)
+
+// parseField parses a plain comma-separated field into a bitset
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		itemBits, err := parseItem(item, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+// parseItem parses *, ?, n, n-m, and any of those with a /step
+func parseItem(item string, b bounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+	start, end := b.min, b.max
+	step := 1
+
+	switch rangePart {
+	case "*", "?":
+	default:
+		lo, hi, isRange := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = b.value(lo); err != nil {
+			return 0, err
+		}
+		end = start
+		if isRange {
+			if end, err = b.value(hi); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			// 5/20 means every 20 starting at 5
+			end = b.max
+		}
+	}
+
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf(`invalid step "%s" in %s field`, stepPart, b.name)
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf(`range "%s" in %s field runs backward`, item, b.name)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+// value parses a number or name and checks it against the bounds
+func (b bounds) value(s string) (int, error) {
+	if n, ok := b.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf(`invalid value "%s" in %s field`, s, b.name)
+	}
+	if n < b.min || n > b.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", b.name, n, b.min, b.max)
+	}
+	return n, nil
+}
+
+// parseDayOfMonth handles the plain syntax plus L, L-n, nW and LW
+func (s *Schedule) parseDayOfMonth(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+
+		switch {
+		case upper == "L":
+			s.domLast = append(s.domLast, 0)
+		case upper == "LW":
+			s.domLastWeekday = true
+		case strings.HasPrefix(upper, "L-"):
+			n, err := strconv.Atoi(upper[2:])
+			if err != nil || n < 0 || n > 30 {
+				return fmt.Errorf(`invalid day of month "%s"`, item)
+			}
+			s.domLast = append(s.domLast, n)
+		case strings.HasSuffix(upper, "W"):
+			n, err := domBounds.value(upper[:len(upper)-1])
+			if err != nil {
+				return err
+			}
+			s.domNearestWeekday = append(s.domNearestWeekday, n)
+		default:
+			bits, err := parseItem(item, domBounds)
+			if err != nil {
+				return err
+			}
+			s.dom |= bits
+		}
+	}
+
+	// Judge "any" by what the field covers rather than how it is
+	// written, so */1 and 1-31 combine with day-of-week like * does
+	s.domAny = s.dom == allDays
+
+	return nil
+}
+
+// parseDayOfWeek handles the plain syntax plus dL and d#n
+func (s *Schedule) parseDayOfWeek(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+
+		switch {
+		case strings.Contains(upper, "#"):
+			dayPart, nthPart, _ := strings.Cut(upper, "#")
+			day, err := dowBounds.value(dayPart)
+			if err != nil {
+				return err
+			}
+			nth, err := strconv.Atoi(nthPart)
+			if err != nil || nth < 1 || nth > 5 {
+				return fmt.Errorf(`invalid day of week "%s"`, item)
+			}
+			s.dowNth = append(s.dowNth, nthWeekday{weekday: time.Weekday(day % 7), nth: nth})
+		case len(upper) > 1 && strings.HasSuffix(upper, "L"):
+			day, err := dowBounds.value(upper[:len(upper)-1])
+			if err != nil {
+				return err
+			}
+			s.dowLast |= 1 << uint(day%7)
+		default:
+			bits, err := parseItem(item, dowBounds)
+			if err != nil {
+				return err
+			}
+			// 7 is an alias for Sunday
+			if bits&(1<<7) != 0 {
+				bits = bits&^(1<<7) | 1
+			}
+			s.dow |= bits
+		}
+	}
+
+	s.dowAny = s.dow == allWeekdays
+
+	return nil
+}
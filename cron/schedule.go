@@ -0,0 +1,265 @@
+package cron
+
+import "time"
+
+// searchYears bounds how far Next and Prev look before giving up.
+// Eight years covers the longest gap between Feb 29s.
+const searchYears = 8
+
+// Schedule is a parsed cron expression bound to a location
+type Schedule struct {
+	spec string
+	loc  *time.Location
+
+	second uint64
+	minute uint64
+	hour   uint64
+	month  uint64
+
+	dom               uint64
+	domAny            bool
+	domLast           []int // days before the last day of the month (L, L-n)
+	domLastWeekday    bool  // LW
+	domNearestWeekday []int // nW
+
+	dow     uint64
+	dowAny  bool
+	dowLast uint64 // dL, one bit per weekday
+	dowNth  []nthWeekday
+}
+
+// nthWeekday is the d#n day-of-week syntax
+type nthWeekday struct {
+	weekday time.Weekday
+	nth     int
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Location returns the location the schedule is evaluated in
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// Next returns the first time after t that matches the schedule,
+// or the zero time if none occurs within eight years.
+//
+// Matching is on the wall clock in the schedule's location. A time
+// skipped by a spring-forward transition (2:30 on the day clocks jump
+// from 2:00 to 3:00) fires at the equivalent instant after the jump
+// (3:30), and a time repeated by a fall-back transition fires only
+// once, at its first occurrence.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	year, month, day := t.Date()
+	startKey := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	limit := time.Date(year+searchYears, month, day, 0, 0, 0, 0, time.UTC)
+
+	for d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC); d.Before(limit); d = d.AddDate(0, 0, 1) {
+		if !s.has(s.month, int(d.Month())) {
+			// skip to the last day of the month
+			d = time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(d) {
+			continue
+		}
+
+		firstDay := d.Year() == year && d.Month() == month && d.Day() == day
+
+		for h := 0; h < 24; h++ {
+			if !s.has(s.hour, h) {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if !s.has(s.minute, m) {
+					continue
+				}
+				for sec := 0; sec < 60; sec++ {
+					if !s.has(s.second, sec) {
+						continue
+					}
+					// DST never moves the wall clock more than a few hours,
+					// so earlier times on the first day can't be after t
+					if firstDay && h*3600+m*60+sec < startKey-3*3600 {
+						continue
+					}
+					if candidate := s.resolve(d, h, m, sec); candidate.After(t) {
+						return candidate
+					}
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// Prev returns the last time before t that matches the schedule,
+// or the zero time if none occurred within eight years. DST
+// transitions are handled as in Next.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	t = t.In(s.loc)
+	year, month, day := t.Date()
+	startKey := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	limit := time.Date(year-searchYears, month, day, 0, 0, 0, 0, time.UTC)
+
+	for d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC); d.After(limit); d = d.AddDate(0, 0, -1) {
+		if !s.has(s.month, int(d.Month())) {
+			// skip to the first day of the month
+			d = time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(d) {
+			continue
+		}
+
+		firstDay := d.Year() == year && d.Month() == month && d.Day() == day
+
+		for h := 23; h >= 0; h-- {
+			if !s.has(s.hour, h) {
+				continue
+			}
+			for m := 59; m >= 0; m-- {
+				if !s.has(s.minute, m) {
+					continue
+				}
+				for sec := 59; sec >= 0; sec-- {
+					if !s.has(s.second, sec) {
+						continue
+					}
+					if firstDay && h*3600+m*60+sec > startKey+3*3600 {
+						continue
+					}
+					if candidate := s.resolve(d, h, m, sec); candidate.Before(t) {
+						return candidate
+					}
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// has reports whether bit n is set
+func (s *Schedule) has(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
+
+// dayMatches applies the day-of-month and day-of-week fields. As in
+// standard cron, when both are restricted a day matching either fires.
+func (s *Schedule) dayMatches(d time.Time) bool {
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.dowAny:
+		return s.domMatches(d)
+	case s.domAny:
+		return s.dowMatches(d)
+	default:
+		return s.domMatches(d) || s.dowMatches(d)
+	}
+}
+
+func (s *Schedule) domMatches(d time.Time) bool {
+	day := d.Day()
+	last := lastDay(d)
+
+	if s.has(s.dom, day) {
+		return true
+	}
+	for _, n := range s.domLast {
+		if day == last-n {
+			return true
+		}
+	}
+	if s.domLastWeekday && day == nearestWeekday(d, last) {
+		return true
+	}
+	for _, n := range s.domNearestWeekday {
+		if n <= last && day == nearestWeekday(d, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schedule) dowMatches(d time.Time) bool {
+	weekday := d.Weekday()
+
+	if s.has(s.dow, int(weekday)) {
+		return true
+	}
+	if s.has(s.dowLast, int(weekday)) && d.Day()+7 > lastDay(d) {
+		return true
+	}
+	for _, n := range s.dowNth {
+		if n.weekday == weekday && (d.Day()-1)/7+1 == n.nth {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve turns a wall-clock time on date d into an instant in the
+// schedule's location, settling DST gaps and overlaps (see Next)
+func (s *Schedule) resolve(d time.Time, hour, minute, second int) time.Time {
+	wall := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, second, 0, time.UTC)
+	approx := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, second, 0, s.loc)
+
+	_, offsetBefore := approx.Add(-12 * time.Hour).Zone()
+	_, offsetAfter := approx.Add(12 * time.Hour).Zone()
+
+	var result time.Time
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(s.loc)
+		if !sameWallClock(candidate, wall) {
+			continue
+		}
+		if result.IsZero() || candidate.Before(result) {
+			result = candidate
+		}
+	}
+
+	if result.IsZero() {
+		// In a spring-forward gap: keep the offset from before the jump
+		result = wall.Add(-time.Duration(offsetBefore) * time.Second).In(s.loc)
+	}
+
+	return result
+}
+
+func sameWallClock(t time.Time, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
+}
+
+// lastDay returns the number of days in d's month
+func lastDay(d time.Time) int {
+	return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday closest to day n of d's month
+// without leaving the month
+func nearestWeekday(d time.Time, n int) int {
+	last := lastDay(d)
+	switch time.Date(d.Year(), d.Month(), n, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if n == 1 {
+			return 3
+		}
+		return n - 1
+	case time.Sunday:
+		if n == last {
+			return n - 2
+		}
+		return n + 1
+	}
+	return n
+}
@@ -0,0 +1,144 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestNext(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		// spring forward: 2:30 doesn't exist on 2026-03-08, so it fires at 3:30 EDT
+		{"30 2 * * *", "2026-03-07T12:00:00-05:00", "2026-03-08T03:30:00-04:00"},
+		{"30 2 * * *", "2026-03-08T03:30:00-04:00", "2026-03-09T02:30:00-04:00"},
+		// fall back: 1:30 happens twice on 2026-11-01 and fires only the first time
+		{"30 1 * * *", "2026-10-31T12:00:00-04:00", "2026-11-01T01:30:00-04:00"},
+		{"30 1 * * *", "2026-11-01T01:30:00-04:00", "2026-11-02T01:30:00-05:00"},
+		{"0 * * * *", "2026-11-01T01:30:00-04:00", "2026-11-01T02:00:00-05:00"},
+
+		{"0 0 29 2 *", "2026-01-01T00:00:00-05:00", "2028-02-29T00:00:00-05:00"},
+		{"0 0 L * *", "2026-02-10T00:00:00-05:00", "2026-02-28T00:00:00-05:00"},
+		{"0 0 L-2 * *", "2026-02-10T00:00:00-05:00", "2026-02-26T00:00:00-05:00"},
+		// May 2026 ends on a Sunday, February 2026 on a Saturday
+		{"0 0 LW * *", "2026-05-01T00:00:00-04:00", "2026-05-29T00:00:00-04:00"},
+		{"0 0 LW * *", "2026-02-01T00:00:00-05:00", "2026-02-27T00:00:00-05:00"},
+		// August 15 2026 is a Saturday; August 1 is a Saturday too
+		{"0 0 15W * *", "2026-08-01T12:00:00-04:00", "2026-08-14T00:00:00-04:00"},
+		{"0 0 1W * *", "2026-07-31T12:00:00-04:00", "2026-08-03T00:00:00-04:00"},
+		// October 31 2026 is a Saturday
+		{"0 0 * * 5L", "2026-10-01T00:00:00-04:00", "2026-10-30T00:00:00-04:00"},
+		{"0 0 * * FRIL", "2026-10-01T00:00:00-04:00", "2026-10-30T00:00:00-04:00"},
+		// October 2026 has only four Mondays
+		{"0 0 * * MON#5", "2026-10-01T00:00:00-04:00", "2026-11-30T00:00:00-05:00"},
+		{"0 0 * * MON#2", "2026-10-01T00:00:00-04:00", "2026-10-12T00:00:00-04:00"},
+
+		// a restricted day of month ORs with a restricted day of week...
+		{"0 0 13 * MON", "2026-10-06T12:00:00-04:00", "2026-10-12T00:00:00-04:00"},
+		{"0 0 13 * MON", "2026-10-12T12:00:00-04:00", "2026-10-13T00:00:00-04:00"},
+		// ...but a day of month that covers every day leaves only the weekday
+		{"0 0 */1 * MON", "2026-10-13T12:00:00-04:00", "2026-10-19T00:00:00-04:00"},
+		{"0 0 1-31 * MON", "2026-10-13T12:00:00-04:00", "2026-10-19T00:00:00-04:00"},
+		{"0 0 13 * */1", "2026-10-06T12:00:00-04:00", "2026-10-13T00:00:00-04:00"},
+		{"0 0 13 * 0-7", "2026-10-06T12:00:00-04:00", "2026-10-13T00:00:00-04:00"},
+
+		{"*/15 9-17 * * MON-FRI", "2026-10-16T17:50:00-04:00", "2026-10-19T09:00:00-04:00"},
+		{"30 */20 * * * *", "2026-10-16T10:00:31-04:00", "2026-10-16T10:20:30-04:00"},
+		{"@yearly", "2026-10-16T10:00:00-04:00", "2027-01-01T00:00:00-05:00"},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.spec, ny)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.spec, err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, tt.from)
+		want, _ := time.Parse(time.RFC3339, tt.want)
+
+		got := s.Next(from)
+		if !got.Equal(want) {
+			t.Errorf("%q.Next(%s) = %s, want %s", tt.spec, tt.from, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"30 2 * * *", "2026-03-08T12:00:00-04:00", "2026-03-08T03:30:00-04:00"},
+		{"30 1 * * *", "2026-11-01T02:00:00-05:00", "2026-11-01T01:30:00-04:00"},
+		{"0 0 29 2 *", "2027-06-01T00:00:00-04:00", "2024-02-29T00:00:00-05:00"},
+		{"0 0 LW * *", "2026-06-01T00:00:00-04:00", "2026-05-29T00:00:00-04:00"},
+		{"0 0 * * MON#5", "2026-11-01T00:00:00-04:00", "2026-08-31T00:00:00-04:00"},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.spec, ny)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.spec, err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, tt.from)
+		want, _ := time.Parse(time.RFC3339, tt.want)
+
+		got := s.Prev(from)
+		if !got.Equal(want) {
+			t.Errorf("%q.Prev(%s) = %s, want %s", tt.spec, tt.from, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Feb 30 Next = %s, want zero time", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"@fortnightly",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * L-31 * *",
+		"* * 32W * *",
+		"* * * * MON#6",
+		"* * * * MON#0",
+		"* * * * XL",
+	} {
+		if _, err := Parse(spec, time.UTC); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", spec)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bjbigler/utils"
+)
+
+// Job is a function run by the Scheduler. The context is cancelled
+// when the scheduler's Run context is cancelled.
+type Job func(ctx context.Context) error
+
+type entry struct {
+	name     string
+	schedule *Schedule
+	job      Job
+	next     time.Time
+	running  bool
+}
+
+// Scheduler runs jobs in-process on cron schedules. Each run is
+// recorded with utils.TimeTrack; errors are written with
+// utils.LogToStdError. A job that is still running when it comes
+// due again is skipped for that occurrence.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []*entry
+	wake    chan struct{}
+}
+
+// NewScheduler returns an empty Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{wake: make(chan struct{}, 1)}
+}
+
+// Add registers *job* under *name* to run on *schedule*. Jobs can be
+// added before or while the scheduler runs.
+func (s *Scheduler) Add(name string, schedule *Schedule, job Job) {
+	s.mu.Lock()
+	s.entries = append(s.entries, &entry{
+		name:     name,
+		schedule: schedule,
+		job:      job,
+		next:     schedule.Next(time.Now()),
+	})
+	s.mu.Unlock()
+
+	// Let Run recalculate its timer
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// AddFunc parses *spec* in *loc* (see Parse) and registers *job*
+func (s *Scheduler) AddFunc(name string, spec string, loc *time.Location, job Job) error {
+	schedule, err := Parse(spec, loc)
+	if err != nil {
+		return err
+	}
+	s.Add(name, schedule, job)
+	return nil
+}
+
+// Run starts due jobs until ctx is cancelled, then waits for running
+// jobs to return. It always returns ctx.Err().
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		timer := time.NewTimer(s.untilNext())
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-s.wake:
+			timer.Stop()
+		case now := <-timer.C:
+			s.startDue(ctx, now, &wg)
+		}
+	}
+}
+
+// untilNext returns the wait until the earliest scheduled run
+func (s *Scheduler) untilNext() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// With nothing scheduled, sleep until Add wakes us
+	wait := 24 * time.Hour
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+		if until := time.Until(e.next); until < wait {
+			wait = until
+		}
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// startDue launches every job whose next run is at or before now
+func (s *Scheduler) startDue(ctx context.Context, now time.Time, wg *sync.WaitGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+
+		e.next = e.schedule.Next(now)
+
+		if e.running {
+			continue
+		}
+		e.running = true
+
+		wg.Add(1)
+		go func(e *entry) {
+			defer wg.Done()
+			s.run(ctx, e)
+		}(e)
+	}
+}
+
+// run executes a single job, recording its duration and any error or panic
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	defer func() {
+		if r := recover(); r != nil {
+			utils.LogToStdError("cron", fmt.Sprintf("%s panicked: %v", e.name, r))
+		}
+
+		s.mu.Lock()
+		e.running = false
+		s.mu.Unlock()
+	}()
+
+	defer utils.TimeTrack(time.Now(), e.name)
+
+	if err := e.job(ctx); err != nil {
+		utils.LogToStdError("cron", fmt.Sprintf("%s failed: %v", e.name, err))
+	}
+}
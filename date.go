@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date with no time of day or location, for
+// birthdays, seminar dates, academic-year boundaries and the like.
+// Unlike a time.Time at midnight, it can't drift to the previous day
+// when moved between zones. The zero Date is 0000-00-00 and IsZero.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the date year-month-day, normalizing out-of-range
+// values the way time.Date does (e.g. Oct 32 => Nov 1)
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns t's date in t's location. Use DateOf(t.In(loc)) for
+// the date somewhere else.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Today returns the current date in *loc*
+func Today(loc *time.Location) Date {
+	return DateOf(time.Now().In(loc))
+}
+
+// ParseCivilDate parses a date formatted yyyy-mm-dd
+func ParseCivilDate(candidate string) (Date, error) {
+	t, err := time.Parse("2006-01-02", candidate)
+	if err != nil {
+		return Date{}, fmt.Errorf(`date "%s" not in right format`, candidate)
+	}
+	return DateOf(t), nil
+}
+
+// String formats the date as yyyy-mm-dd
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero Date
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Valid reports whether d is a real calendar date
+func (d Date) Valid() bool {
+	return d.Month >= time.January && d.Month <= time.December &&
+		d.Day >= 1 && d.Day <= DaysIn(d.Month, d.Year)
+}
+
+// In returns 12AM of d in *loc*
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns d plus n days (n may be negative)
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// AddMonths returns d plus n months. Days past the end of the target
+// month go to its last day, so Jan 31 + 1 month is Feb 28 (or 29).
+func (d Date) AddMonths(n int) Date {
+	return DateOf(LeapDayFeb28.addMonths(d.Year, d.Month, d.Day, n))
+}
+
+// AddYears returns d plus n years; Feb 29 goes to Feb 28 in common years
+func (d Date) AddYears(n int) Date {
+	return d.AddMonths(n * 12)
+}
+
+// Compare returns -1 if d is before other, 1 if after, and 0 if equal
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return sign(d.Year - other.Year)
+	case d.Month != other.Month:
+		return sign(int(d.Month - other.Month))
+	default:
+		return sign(d.Day - other.Day)
+	}
+}
+
+// Before reports whether d is before *other*
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After reports whether d is after *other*
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// Equal reports whether d and *other* are the same date
+func (d Date) Equal(other Date) bool {
+	return d == other
+}
+
+// DaysSince returns the number of days from *other* to d, negative
+// if d is earlier
+func (d Date) DaysSince(other Date) int {
+	return civilDaysBetween(other.In(time.UTC), d.In(time.UTC))
+}
+
+// Weekday returns the day of the week
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// ISOWeek returns the ISO 8601 week containing d
+func (d Date) ISOWeek() ISOWeek {
+	return ISOWeekOf(d.In(time.UTC))
+}
+
+// MarshalText formats d as yyyy-mm-dd, or "" for the zero Date.
+// It also serves encoding/json.
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses yyyy-mm-dd; "" yields the zero Date.
+// It also serves encoding/json.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseCivilDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for DATE columns and yyyy-mm-dd strings.
+// NULL scans to the zero Date.
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Date", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer, storing yyyy-mm-dd, or NULL for
+// the zero Date
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
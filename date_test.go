@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateScanValue(t *testing.T) {
+	want := NewDate(2026, time.October, 18)
+
+	tests := []struct {
+		src  interface{}
+		want Date
+	}{
+		{nil, Date{}},
+		{time.Date(2026, 10, 18, 23, 30, 0, 0, time.FixedZone("EDT", -4*3600)), want},
+		{"2026-10-18", want},
+		{[]byte("2026-10-18"), want},
+		{"", Date{}},
+		{[]byte{}, Date{}},
+	}
+	for _, tt := range tests {
+		d := NewDate(1999, time.January, 1)
+		if err := d.Scan(tt.src); err != nil {
+			t.Errorf("Scan(%#v) error: %v", tt.src, err)
+			continue
+		}
+		if d != tt.want {
+			t.Errorf("Scan(%#v) = %s, want %s", tt.src, d, tt.want)
+		}
+	}
+
+	for _, src := range []interface{}{42, "10/18/2026", []byte("2026-13-01")} {
+		var d Date
+		if err := d.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) = %s, want error", src, d)
+		}
+	}
+
+	// Value round-trips through Scan, with the zero Date as NULL
+	for _, d := range []Date{want, {}} {
+		v, err := d.Value()
+		if err != nil {
+			t.Fatalf("Value(%s) error: %v", d, err)
+		}
+		var back Date
+		if err := back.Scan(v); err != nil || back != d {
+			t.Errorf("Scan(Value(%s)) = %s, %v", d, back, err)
+		}
+	}
+	if v, _ := (Date{}).Value(); v != nil {
+		t.Errorf("zero Date Value = %#v, want nil", v)
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	type row struct {
+		Date Date `json:"date"`
+	}
+
+	out, err := json.Marshal(row{NewDate(2026, time.October, 18)})
+	if err != nil || string(out) != `{"date":"2026-10-18"}` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+	out, err = json.Marshal(row{})
+	if err != nil || string(out) != `{"date":""}` {
+		t.Errorf("Marshal of zero Date = %s, %v", out, err)
+	}
+
+	for _, in := range []string{`{"date":null}`, `{"date":""}`, `{}`} {
+		var r row
+		if err := json.Unmarshal([]byte(in), &r); err != nil || !r.Date.IsZero() {
+			t.Errorf("Unmarshal(%s) = %s, %v, want the zero Date", in, r.Date, err)
+		}
+	}
+
+	var r row
+	if err := json.Unmarshal([]byte(`{"date":"2026-10-18"}`), &r); err != nil || r.Date != NewDate(2026, 10, 18) {
+		t.Errorf("Unmarshal = %s, %v", r.Date, err)
+	}
+	if err := json.Unmarshal([]byte(`{"date":"2026-02-30"}`), &r); err == nil {
+		t.Error("Unmarshal accepted Feb 30")
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	tests := []struct {
+		got, want Date
+	}{
+		{NewDate(2026, time.October, 32), NewDate(2026, time.November, 1)},
+		{NewDate(2026, 12, 31).AddDays(1), NewDate(2027, 1, 1)},
+		{NewDate(2026, 3, 1).AddDays(-1), NewDate(2026, 2, 28)},
+		{NewDate(2026, 1, 31).AddMonths(1), NewDate(2026, 2, 28)},
+		{NewDate(2024, 1, 31).AddMonths(1), NewDate(2024, 2, 29)},
+		{NewDate(2026, 3, 31).AddMonths(-1), NewDate(2026, 2, 28)},
+		{NewDate(2024, 2, 29).AddYears(1), NewDate(2025, 2, 28)},
+		{NewDate(2024, 2, 29).AddYears(4), NewDate(2028, 2, 29)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s, want %s", tt.got, tt.want)
+		}
+	}
+
+	a, b := NewDate(2026, 1, 1), NewDate(2026, 3, 1)
+	if got := b.DaysSince(a); got != 59 {
+		t.Errorf("DaysSince = %d, want 59", got)
+	}
+	if got := a.DaysSince(b); got != -59 {
+		t.Errorf("reversed DaysSince = %d, want -59", got)
+	}
+	if !a.Before(b) || a.After(b) || a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Error("Compare, Before and After disagree")
+	}
+	if got := NewDate(2026, 10, 18).Weekday(); got != time.Sunday {
+		t.Errorf("Weekday = %s, want Sunday", got)
+	}
+	if (Date{Year: 2026, Month: 2, Day: 29}).Valid() || !NewDate(2024, 2, 29).Valid() {
+		t.Error("Valid is wrong about Feb 29")
+	}
+}
@@ -0,0 +1,135 @@
+package utils
+
+import "time"
+
+// LeapDayRule decides where an anniversary lands when its day
+// doesn't exist in the target month, e.g. a Feb 29 birthday in
+// a common year, or a Jan 31 start date one month later.
+type LeapDayRule int
+
+const (
+	// LeapDayFeb28 moves the anniversary back to the last day of the
+	// month (Feb 29 => Feb 28, Jan 31 => Feb 28).
+	LeapDayFeb28 LeapDayRule = iota
+	// LeapDayMar1 moves the anniversary forward to the first day of the
+	// following month (Feb 29 => Mar 1, Jan 31 => Mar 1).
+	LeapDayMar1
+)
+
+// DiffYMD returns the calendar difference between a and b in years,
+// months and days, counted the way a person would: Jan 15 to Mar 20
+// is 2 months and 5 days, regardless of month length. Both times are
+// first converted to dates in *loc*. If b is before a, all three
+// values are negative. Missing days (Feb 29, the 31st) follow LeapDayFeb28;
+// use LeapDayRule.DiffYMD for the alternative.
+func DiffYMD(a, b time.Time, loc *time.Location) (years, months, days int) {
+	return LeapDayFeb28.DiffYMD(a, b, loc)
+}
+
+// Age returns the completed years between *birthdate* and *at*,
+// using LeapDayFeb28 for Feb 29 birthdays.
+func Age(birthdate, at time.Time) int {
+	return LeapDayFeb28.Age(birthdate, at)
+}
+
+// NextAnniversary returns the first anniversary of *date* after *after*,
+// using LeapDayFeb28 for Feb 29 dates.
+func NextAnniversary(date, after time.Time) time.Time {
+	return LeapDayFeb28.NextAnniversary(date, after)
+}
+
+// DiffYMD is DiffYMD using rule r for missing days
+func (r LeapDayRule) DiffYMD(a, b time.Time, loc *time.Location) (years, months, days int) {
+	return r.diffCivil(a.In(loc), b.In(loc))
+}
+
+// diffCivil is DiffYMD on the calendar dates of a and b as they stand
+func (r LeapDayRule) diffCivil(a, b time.Time) (years, months, days int) {
+	sign := 1
+	if civilBefore(b, a) {
+		a, b = b, a
+		sign = -1
+	}
+
+	ay, am, ad := a.Date()
+	by, bm, _ := b.Date()
+
+	totalMonths := (by-ay)*12 + int(bm-am)
+	anniversary := r.addMonths(ay, am, ad, totalMonths)
+	if civilBefore(b, anniversary) {
+		totalMonths--
+		anniversary = r.addMonths(ay, am, ad, totalMonths)
+	}
+
+	days = civilDaysBetween(anniversary, b)
+
+	return sign * (totalMonths / 12), sign * (totalMonths % 12), sign * days
+}
+
+// Age returns the completed years between *birthdate* and *at*.
+// Both are taken as calendar dates in their own locations, so a birthdate
+// stored as midnight UTC isn't shifted to the previous day. It is negative
+// if *at* is before *birthdate*.
+func (r LeapDayRule) Age(birthdate, at time.Time) int {
+	years, _, _ := r.diffCivil(birthdate, at)
+	return years
+}
+
+// NextAnniversary returns 12AM (in after's location) of the first
+// anniversary of *date* that falls after *after*. As with Age, *date* is
+// taken as a calendar date in its own location. If *after* precedes
+// *date*, *date* itself is the next anniversary.
+func (r LeapDayRule) NextAnniversary(date, after time.Time) time.Time {
+	loc := after.Location()
+	y, m, d := date.Date()
+
+	if civilBefore(after, date) {
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+
+	n := after.Year() - y
+	anniversary := r.addMonths(y, m, d, n*12)
+	if !civilBefore(after, anniversary) {
+		anniversary = r.addMonths(y, m, d, (n+1)*12)
+	}
+
+	ay, am, ad := anniversary.Date()
+	return time.Date(ay, am, ad, 0, 0, 0, 0, loc)
+}
+
+// addMonths returns 12AM of the date *n* months after y-m-d, resolving
+// days that don't exist in the target month according to r
+func (r LeapDayRule) addMonths(y int, m time.Month, d int, n int) time.Time {
+	targetYear, targetMonth := addToMonth(y, m, n)
+
+	if last := DaysIn(targetMonth, targetYear); d > last {
+		if r == LeapDayMar1 {
+			return time.Date(targetYear, targetMonth+1, 1, 0, 0, 0, 0, time.UTC)
+		}
+		d = last
+	}
+
+	return time.Date(targetYear, targetMonth, d, 0, 0, 0, 0, time.UTC)
+}
+
+// addToMonth adds n months to year-month, normalizing the result
+func addToMonth(year int, month time.Month, n int) (int, time.Month) {
+	total := year*12 + int(month) - 1 + n
+	return floorDiv(total, 12), time.Month(total - floorDiv(total, 12)*12 + 1)
+}
+
+// civilBefore reports whether a's calendar date is before b's,
+// ignoring the time of day and location
+func civilBefore(a, b time.Time) bool {
+	return civilDaysBetween(a, b) > 0
+}
+
+// civilDaysBetween returns the number of calendar days from a's date
+// to b's date, ignoring the time of day and location
+func civilDaysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	start := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	end := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int((end.Unix() - start.Unix()) / 86400)
+}
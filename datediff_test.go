@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffYMD(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		rule                LeapDayRule
+		a, b                time.Time
+		years, months, days int
+	}{
+		{LeapDayFeb28, date(2026, 1, 15), date(2026, 3, 20), 0, 2, 5},
+		{LeapDayFeb28, date(2026, 1, 15), date(2026, 1, 15), 0, 0, 0},
+
+		// end of month: Jan 31 + 1 month is the last day of February
+		{LeapDayFeb28, date(2026, 1, 31), date(2026, 2, 28), 0, 1, 0},
+		{LeapDayFeb28, date(2026, 1, 31), date(2026, 3, 1), 0, 1, 1},
+		{LeapDayFeb28, date(2024, 1, 31), date(2024, 2, 29), 0, 1, 0},
+		{LeapDayFeb28, date(2024, 1, 31), date(2024, 2, 28), 0, 0, 28},
+		{LeapDayMar1, date(2026, 1, 31), date(2026, 2, 28), 0, 0, 28},
+		{LeapDayMar1, date(2026, 1, 31), date(2026, 3, 1), 0, 1, 0},
+
+		// leap day anniversaries in common years
+		{LeapDayFeb28, date(2024, 2, 29), date(2025, 2, 28), 1, 0, 0},
+		{LeapDayMar1, date(2024, 2, 29), date(2025, 2, 28), 0, 11, 30},
+		{LeapDayMar1, date(2024, 2, 29), date(2025, 3, 1), 1, 0, 0},
+		{LeapDayFeb28, date(2024, 2, 29), date(2028, 2, 28), 3, 11, 30},
+		{LeapDayFeb28, date(2024, 2, 29), date(2028, 2, 29), 4, 0, 0},
+
+		// reversed ranges are negative
+		{LeapDayFeb28, date(2026, 3, 20), date(2026, 1, 15), 0, -2, -5},
+		{LeapDayFeb28, date(2025, 2, 28), date(2024, 2, 29), -1, 0, 0},
+		{LeapDayFeb28, date(2026, 10, 18), date(1990, 5, 1), -36, -5, -17},
+	}
+
+	for _, tt := range tests {
+		y, m, d := tt.rule.DiffYMD(tt.a, tt.b, time.UTC)
+		if y != tt.years || m != tt.months || d != tt.days {
+			t.Errorf("rule %d DiffYMD(%s, %s) = %d, %d, %d, want %d, %d, %d", tt.rule,
+				tt.a.Format("2006-01-02"), tt.b.Format("2006-01-02"), y, m, d, tt.years, tt.months, tt.days)
+		}
+	}
+}
+
+func TestDiffYMDUsesLocation(t *testing.T) {
+	// 03:00 UTC on Mar 1 is still Feb 28 in New York
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tz database unavailable: %v", err)
+	}
+	a := time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)
+	b := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+
+	if y, m, d := DiffYMD(a, b, ny); y != 0 || m != 1 || d != 0 {
+		t.Errorf("DiffYMD in New York = %d, %d, %d, want 0, 1, 0", y, m, d)
+	}
+}
+
+func TestAgeAndNextAnniversary(t *testing.T) {
+	leapling := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	if got := Age(leapling, at); got != 1 {
+		t.Errorf("Age = %d, want 1", got)
+	}
+	if got := LeapDayMar1.Age(leapling, at); got != 0 {
+		t.Errorf("LeapDayMar1.Age = %d, want 0", got)
+	}
+	if got := Age(at, leapling); got != -1 {
+		t.Errorf("reversed Age = %d, want -1", got)
+	}
+
+	next := NextAnniversary(leapling, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("NextAnniversary = %s, want %s", next, want)
+	}
+	next = LeapDayMar1.NextAnniversary(leapling, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("LeapDayMar1.NextAnniversary = %s, want %s", next, want)
+	}
+}
@@ -0,0 +1,581 @@
+package utils
+
+import (
+	"strings"
+)
+
+/*
+DoubleMetaphone returns Lawrence Philips' Double Metaphone codes for *s*:
+a primary code for the most likely English pronunciation and an alternate
+for a common variant, each up to four characters ("0" stands for "th",
+"X" for "sh"). Names that sound alike share a code even when spelled
+differently: "Smith" => (SM0, XMT) and "Schmidt" => (XMT, SMT) match on
+XMT; "Catherine" and "Katherine" both give (K0RN, KTRN). Accents are folded
+first; s without letters gives ("", "").
+*/
+func DoubleMetaphone(s string) (primary, alternate string) {
+	value := strings.ToUpper(strings.Join(strings.Fields(ReplaceAccents(s)), " "))
+	if strings.IndexFunc(value, func(r rune) bool { return r >= 'A' && r <= 'Z' }) < 0 {
+		return "", ""
+	}
+
+	m := &metaphone{value: value, max: doubleMetaphoneLength}
+	m.slavoGermanic = strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") || strings.Contains(value, "WITZ")
+	m.encode()
+	return m.primary.String(), m.alternate.String()
+}
+
+// metaphone holds the state of one Double Metaphone encoding
+type metaphone struct {
+	value         string
+	max           int
+	slavoGermanic bool
+	primary       strings.Builder
+	alternate     strings.Builder
+}
+
+func (m *metaphone) encode() {
+	index := 0
+	for _, silent := range []string{"GN", "KN", "PN", "WR", "PS"} {
+		if strings.HasPrefix(m.value, silent) {
+			index = 1
+			break
+		}
+	}
+
+	for !m.complete() && index < len(m.value) {
+		switch m.at(index) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				m.add("A")
+			}
+			index++
+		case 'B':
+			m.add("P")
+			index = m.skipDouble(index, 'B')
+		case 'C':
+			index = m.handleC(index)
+		case 'D':
+			index = m.handleD(index)
+		case 'F':
+			m.add("F")
+			index = m.skipDouble(index, 'F')
+		case 'G':
+			index = m.handleG(index)
+		case 'H':
+			index = m.handleH(index)
+		case 'J':
+			index = m.handleJ(index)
+		case 'K':
+			m.add("K")
+			index = m.skipDouble(index, 'K')
+		case 'L':
+			index = m.handleL(index)
+		case 'M':
+			m.add("M")
+			if m.at(index+1) == 'M' || (m.contains(index-1, "UMB") &&
+				(index+1 == len(m.value)-1 || m.contains(index+2, "ER"))) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			m.add("N")
+			index = m.skipDouble(index, 'N')
+		case 'P':
+			if m.at(index+1) == 'H' {
+				m.add("F")
+				index += 2
+			} else {
+				m.add("P")
+				index = m.skipIf(index, "P", "B")
+			}
+		case 'Q':
+			m.add("K")
+			index = m.skipDouble(index, 'Q')
+		case 'R':
+			index = m.handleR(index)
+		case 'S':
+			index = m.handleS(index)
+		case 'T':
+			index = m.handleT(index)
+		case 'V':
+			m.add("F")
+			index = m.skipDouble(index, 'V')
+		case 'W':
+			index = m.handleW(index)
+		case 'X':
+			index = m.handleX(index)
+		case 'Z':
+			index = m.handleZ(index)
+		default:
+			index++
+		}
+	}
+}
+
+func (m *metaphone) handleC(index int) int {
+	switch {
+	case m.conditionC0(index):
+		// Various Germanic: "bacher", "macher"
+		m.add("K")
+		return index + 2
+	case index == 0 && m.contains(index, "CAESAR"):
+		m.add("S")
+		return index + 2
+	case m.contains(index, "CH"):
+		return m.handleCH(index)
+	case m.contains(index, "CZ") && !m.contains(index-2, "WICZ"):
+		// "Czerny"
+		m.addPair("S", "X")
+		return index + 2
+	case m.contains(index+1, "CIA"):
+		// "focaccia"
+		m.add("X")
+		return index + 3
+	case m.contains(index, "CC") && !(index == 1 && m.at(0) == 'M'):
+		// Double "cc", but not "McClelland"
+		return m.handleCC(index)
+	case m.containsAny(index, "CK", "CG", "CQ"):
+		m.add("K")
+		return index + 2
+	case m.containsAny(index, "CI", "CE", "CY"):
+		// Italian vs. English
+		if m.containsAny(index, "CIO", "CIE", "CIA") {
+			m.addPair("S", "X")
+		} else {
+			m.add("S")
+		}
+		return index + 2
+	}
+
+	m.add("K")
+	switch {
+	case m.containsAny(index+1, " C", " Q", " G"):
+		// "Mac Caffrey", "Mac Gregor"
+		return index + 3
+	case m.containsAny(index+1, "C", "K", "Q") && !m.containsAny(index+1, "CE", "CI"):
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleCC(index int) int {
+	if m.containsAny(index+2, "I", "E", "H") && !m.contains(index+2, "HU") {
+		// "bellocchio", but not "bacchus"
+		if (index == 1 && m.at(index-1) == 'A') || m.containsAny(index-1, "UCCEE", "UCCES") {
+			// "accident", "accede", "succeed"
+			m.add("KS")
+		} else {
+			// "bacci", "bertucci"
+			m.add("X")
+		}
+		return index + 3
+	}
+
+	// Pierce's rule
+	m.add("K")
+	return index + 2
+}
+
+func (m *metaphone) handleCH(index int) int {
+	switch {
+	case index > 0 && m.contains(index, "CHAE"):
+		// "Michael"
+		m.addPair("K", "X")
+	case m.conditionCH0(index), m.conditionCH1(index):
+		// Greek roots ("chemistry", "chorus") and Germanic "ch" for "kh"
+		m.add("K")
+	case index > 0 && m.contains(0, "MC"):
+		m.add("K")
+	case index > 0:
+		m.addPair("X", "K")
+	default:
+		m.add("X")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleD(index int) int {
+	switch {
+	case m.contains(index, "DG"):
+		if m.containsAny(index+2, "I", "E", "Y") {
+			// "edge"
+			m.add("J")
+			return index + 3
+		}
+		// "Edgar"
+		m.add("TK")
+		return index + 2
+	case m.containsAny(index, "DT", "DD"):
+		m.add("T")
+		return index + 2
+	}
+	m.add("T")
+	return index + 1
+}
+
+func (m *metaphone) handleG(index int) int {
+	switch {
+	case m.at(index+1) == 'H':
+		return m.handleGH(index)
+	case m.at(index+1) == 'N':
+		switch {
+		case index == 1 && isMetaphoneVowel(m.at(0)) && !m.slavoGermanic:
+			m.addPair("KN", "N")
+		case !m.contains(index+2, "EY") && m.at(index+1) != 'Y' && !m.slavoGermanic:
+			m.addPair("N", "KN")
+		default:
+			m.add("KN")
+		}
+		return index + 2
+	case m.contains(index+1, "LI") && !m.slavoGermanic:
+		// "tagliaro"
+		m.addPair("KL", "L")
+		return index + 2
+	case index == 0 && (m.at(index+1) == 'Y' ||
+		m.containsAny(index+1, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		// -ges-, -gep-, -gel-, -gie- at the beginning
+		m.addPair("K", "J")
+		return index + 2
+	case (m.contains(index+1, "ER") || m.at(index+1) == 'Y') &&
+		!m.containsAny(0, "DANGER", "RANGER", "MANGER") &&
+		!m.containsAny(index-1, "E", "I") &&
+		!m.containsAny(index-1, "RGY", "OGY"):
+		// -ger-, -gy-
+		m.addPair("K", "J")
+		return index + 2
+	case m.containsAny(index+1, "E", "I", "Y") || m.containsAny(index-1, "AGGI", "OGGI"):
+		// Italian "biaggi"
+		switch {
+		case m.containsAny(0, "VAN ", "VON ") || m.contains(0, "SCH") || m.contains(index+1, "ET"):
+			// Obviously Germanic
+			m.add("K")
+		case m.contains(index+1, "IER"):
+			m.add("J")
+		default:
+			m.addPair("J", "K")
+		}
+		return index + 2
+	case m.at(index+1) == 'G':
+		m.add("K")
+		return index + 2
+	}
+	m.add("K")
+	return index + 1
+}
+
+func (m *metaphone) handleGH(index int) int {
+	switch {
+	case index > 0 && !isMetaphoneVowel(m.at(index-1)):
+		m.add("K")
+	case index == 0:
+		// "ghislane", "ghiradelli"
+		if m.at(index+2) == 'I' {
+			m.add("J")
+		} else {
+			m.add("K")
+		}
+	case (index > 1 && m.containsAny(index-2, "B", "H", "D")) ||
+		(index > 2 && m.containsAny(index-3, "B", "H", "D")) ||
+		(index > 3 && m.containsAny(index-4, "B", "H")):
+		// Parker's rule: "hugh", "bough", "broughton"
+	case index > 2 && m.at(index-1) == 'U' && m.containsAny(index-3, "C", "G", "L", "R", "T"):
+		// "laugh", "McLaughlin", "cough", "gough", "rough", "tough"
+		m.add("F")
+	case m.at(index-1) != 'I':
+		m.add("K")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleH(index int) int {
+	// Keep only if first and before a vowel, or between two vowels
+	if (index == 0 || isMetaphoneVowel(m.at(index-1))) && isMetaphoneVowel(m.at(index+1)) {
+		m.add("H")
+		return index + 2
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleJ(index int) int {
+	if m.contains(index, "JOSE") || m.contains(0, "SAN ") {
+		// Obviously Spanish: "Jose", "San Jacinto"
+		if (index == 0 && m.at(index+4) == ' ') || len(m.value) == 4 || m.contains(0, "SAN ") {
+			m.add("H")
+		} else {
+			m.addPair("J", "H")
+		}
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		// "Yankelovich", "Jankelowicz"
+		m.addPair("J", "A")
+	case isMetaphoneVowel(m.at(index-1)) && !m.slavoGermanic && (m.at(index+1) == 'A' || m.at(index+1) == 'O'):
+		// Spanish pronunciation of "bajador"
+		m.addPair("J", "H")
+	case index == len(m.value)-1:
+		m.addPair("J", "")
+	case !m.containsAny(index+1, "L", "T", "K", "S", "N", "M", "B", "Z") && !m.containsAny(index-1, "S", "K", "L"):
+		m.add("J")
+	}
+	return m.skipDouble(index, 'J')
+}
+
+func (m *metaphone) handleL(index int) int {
+	if m.at(index+1) != 'L' {
+		m.add("L")
+		return index + 1
+	}
+
+	// Spanish "cabrillo", "gallegos"
+	last := len(m.value) - 1
+	if (index == len(m.value)-3 && m.containsAny(index-1, "ILLO", "ILLA", "ALLE")) ||
+		((m.containsAny(last-1, "AS", "OS") || m.containsAny(last, "A", "O")) && m.contains(index-1, "ALLE")) {
+		m.addPair("L", "")
+	} else {
+		m.add("L")
+	}
+	return index + 2
+}
+
+func (m *metaphone) handleR(index int) int {
+	// French "rogier", but not "hochmeier"
+	if index == len(m.value)-1 && !m.slavoGermanic && m.contains(index-2, "IE") && !m.containsAny(index-4, "ME", "MA") {
+		m.addPair("", "R")
+	} else {
+		m.add("R")
+	}
+	return m.skipDouble(index, 'R')
+}
+
+func (m *metaphone) handleS(index int) int {
+	switch {
+	case m.containsAny(index-1, "ISL", "YSL"):
+		// "island", "isle", "carlisle", "carlysle"
+		return index + 1
+	case index == 0 && m.contains(index, "SUGAR"):
+		m.addPair("X", "S")
+		return index + 1
+	case m.contains(index, "SH"):
+		if m.containsAny(index+1, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			m.add("S")
+		} else {
+			m.add("X")
+		}
+		return index + 2
+	case m.containsAny(index, "SIO", "SIA") || m.contains(index, "SIAN"):
+		// Italian and Armenian
+		if m.slavoGermanic {
+			m.add("S")
+		} else {
+			m.addPair("S", "X")
+		}
+		return index + 3
+	case (index == 0 && m.containsAny(index+1, "M", "N", "L", "W")) || m.contains(index+1, "Z"):
+		// German and anglicized: "Smith" matches "Schmidt", "Snider"
+		// matches "Schneider"; also Slavic -sz-
+		m.addPair("S", "X")
+		return m.skipIf(index, "Z")
+	case m.contains(index, "SC"):
+		return m.handleSC(index)
+	}
+
+	if index == len(m.value)-1 && m.containsAny(index-2, "AI", "OI") {
+		// French "resnais", "artois"
+		m.addPair("", "S")
+	} else {
+		m.add("S")
+	}
+	return m.skipIf(index, "S", "Z")
+}
+
+func (m *metaphone) handleSC(index int) int {
+	switch {
+	case m.at(index+2) == 'H':
+		// Schlesinger's rule
+		switch {
+		case m.containsAny(index+3, "ER", "EN"):
+			// "Schermerhorn", "Schenker"
+			m.addPair("X", "SK")
+		case m.containsAny(index+3, "OO", "UY", "ED", "EM"):
+			// Dutch: "school", "schooner"
+			m.add("SK")
+		case index == 0 && !isMetaphoneVowel(m.at(3)) && m.at(3) != 'W':
+			m.addPair("X", "S")
+		default:
+			m.add("X")
+		}
+	case m.containsAny(index+2, "I", "E", "Y"):
+		m.add("S")
+	default:
+		m.add("SK")
+	}
+	return index + 3
+}
+
+func (m *metaphone) handleT(index int) int {
+	switch {
+	case m.contains(index, "TION"), m.containsAny(index, "TIA", "TCH"):
+		m.add("X")
+		return index + 3
+	case m.contains(index, "TH"), m.contains(index, "TTH"):
+		if m.containsAny(index+2, "OM", "AM") || m.containsAny(0, "VAN ", "VON ") || m.contains(0, "SCH") {
+			// "Thomas", "Thames", or Germanic
+			m.add("T")
+		} else {
+			m.addPair("0", "T")
+		}
+		return index + 2
+	}
+	m.add("T")
+	return m.skipIf(index, "T", "D")
+}
+
+func (m *metaphone) handleW(index int) int {
+	if m.contains(index, "WR") {
+		m.add("R")
+		return index + 2
+	}
+
+	switch {
+	case index == 0 && isMetaphoneVowel(m.at(index+1)):
+		// "Wasserman" matches "Vasserman"
+		m.addPair("A", "F")
+	case index == 0 && m.contains(index, "WH"):
+		// "Uomo" matches "Womo"
+		m.add("A")
+	case (index == len(m.value)-1 && isMetaphoneVowel(m.at(index-1))) ||
+		m.containsAny(index-1, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || m.contains(0, "SCH"):
+		// "Arnow" matches "Arnoff"
+		m.addPair("", "F")
+	case m.containsAny(index, "WICZ", "WITZ"):
+		// Polish "Filipowicz"
+		m.addPair("TS", "FX")
+		return index + 4
+	}
+	return index + 1
+}
+
+func (m *metaphone) handleX(index int) int {
+	if index == 0 {
+		m.add("S")
+		return index + 1
+	}
+
+	// French "breaux"
+	if !(index == len(m.value)-1 && (m.containsAny(index-3, "IAU", "EAU") || m.containsAny(index-2, "AU", "OU"))) {
+		m.add("KS")
+	}
+	return m.skipIf(index, "C", "X")
+}
+
+func (m *metaphone) handleZ(index int) int {
+	if m.at(index+1) == 'H' {
+		// Chinese pinyin "Zhao"
+		m.add("J")
+		return index + 2
+	}
+
+	if m.containsAny(index+1, "ZO", "ZI", "ZA") || (m.slavoGermanic && index > 0 && m.at(index-1) != 'T') {
+		m.addPair("S", "TS")
+	} else {
+		m.add("S")
+	}
+	return m.skipDouble(index, 'Z')
+}
+
+func (m *metaphone) conditionC0(index int) bool {
+	if m.contains(index, "CHIA") {
+		return true
+	}
+	if index <= 1 || isMetaphoneVowel(m.at(index-2)) || !m.contains(index-1, "ACH") {
+		return false
+	}
+	c := m.at(index + 2)
+	return (c != 'I' && c != 'E') || m.containsAny(index-2, "BACHER", "MACHER")
+}
+
+func (m *metaphone) conditionCH0(index int) bool {
+	return index == 0 &&
+		(m.containsAny(index+1, "HARAC", "HARIS") || m.containsAny(index+1, "HOR", "HYM", "HIA", "HEM")) &&
+		!m.contains(0, "CHORE")
+}
+
+func (m *metaphone) conditionCH1(index int) bool {
+	return m.containsAny(0, "VAN ", "VON ") || m.contains(0, "SCH") ||
+		m.containsAny(index-2, "ORCHES", "ARCHIT", "ORCHID") ||
+		m.containsAny(index+2, "T", "S") ||
+		((m.containsAny(index-1, "A", "O", "U", "E") || index == 0) &&
+			(m.containsAny(index+2, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || index+1 == len(m.value)-1))
+}
+
+// at returns the letter at index, or 0 outside the value
+func (m *metaphone) at(index int) byte {
+	if index < 0 || index >= len(m.value) {
+		return 0
+	}
+	return m.value[index]
+}
+
+// contains reports whether the value has *s* at index
+func (m *metaphone) contains(index int, s string) bool {
+	return index >= 0 && index+len(s) <= len(m.value) && m.value[index:index+len(s)] == s
+}
+
+func (m *metaphone) containsAny(index int, options ...string) bool {
+	for _, s := range options {
+		if m.contains(index, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDouble steps past the letter at index and a repeat of it
+func (m *metaphone) skipDouble(index int, c byte) int {
+	if m.at(index+1) == c {
+		return index + 2
+	}
+	return index + 1
+}
+
+// skipIf steps past the letter at index and a following letter in options
+func (m *metaphone) skipIf(index int, options ...string) int {
+	if m.containsAny(index+1, options...) {
+		return index + 2
+	}
+	return index + 1
+}
+
+// add appends s to both codes
+func (m *metaphone) add(s string) {
+	m.addPair(s, s)
+}
+
+// addPair appends to the primary and alternate codes, up to the maximum
+func (m *metaphone) addPair(primary, alternate string) {
+	appendUpTo(&m.primary, primary, m.max)
+	appendUpTo(&m.alternate, alternate, m.max)
+}
+
+func (m *metaphone) complete() bool {
+	return m.primary.Len() >= m.max && m.alternate.Len() >= m.max
+}
+
+func appendUpTo(b *strings.Builder, s string, max int) {
+	if room := max - b.Len(); room > 0 {
+		if len(s) > room {
+			s = s[:room]
+		}
+		b.WriteString(s)
+	}
+}
+
+// isMetaphoneVowel counts Y as a vowel, unlike isPhoneticVowel
+func isMetaphoneVowel(c byte) bool {
+	return strings.IndexByte("AEIOUY", c) >= 0
+}
@@ -0,0 +1,33 @@
+package utils
+
+import "testing"
+
+func TestDoubleMetaphone(t *testing.T) {
+	tests := []struct {
+		in        string
+		primary   string
+		alternate string
+	}{
+		{"", "", ""},
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Thumbail", "0MPL", "TMPL"},
+		{"Xavier", "SF", "SFR"},
+		{"Caesar", "SSR", "SSR"},
+		{"Jose", "HS", "HS"},
+		{"Gnome", "NM", "NM"},
+		{"Knight", "NT", "NT"},
+		{"Philip", "FLP", "FLP"},
+		{"Aubrey", "APR", "APR"},
+		{"Hochmeier", "HKMR", "HKMR"},
+		{"Kuczewski", "KSSK", "KXFS"},
+		{"Arnoff", "ARNF", "ARNF"},
+		{"Zhao", "J", "J"},
+	}
+	for _, tt := range tests {
+		primary, alternate := DoubleMetaphone(tt.in)
+		if primary != tt.primary || alternate != tt.alternate {
+			t.Errorf("DoubleMetaphone(%q) = (%q, %q), want (%q, %q)", tt.in, primary, alternate, tt.primary, tt.alternate)
+		}
+	}
+}
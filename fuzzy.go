@@ -0,0 +1,267 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// FuzzyMatch is one candidate found by FuzzyFind
+type FuzzyMatch struct {
+	Candidate string  `json:"candidate"`
+	Index     int     `json:"index"` // position in the candidate list
+	Score     float64 `json:"score"` // 0 (nothing alike) through 1 (same)
+}
+
+// Levenshtein returns the number of single-rune insertions, deletions and
+// substitutions that turn a into b: "kitten" and "sitting" => 3
+func Levenshtein(a, b string) int {
+	return editDistance([]rune(a), []rune(b), false, -1)
+}
+
+// DamerauLevenshtein is Levenshtein that also counts swapping two adjacent
+// runes as one edit, so "teh" and "the" => 1. It is the optimal string
+// alignment form: a substring is edited at most once.
+func DamerauLevenshtein(a, b string) int {
+	return editDistance([]rune(a), []rune(b), true, -1)
+}
+
+// editDistance computes the Levenshtein distance, or with *transpose* the
+// optimal string alignment distance. With *limit* 0 or more it stops as
+// soon as the distance must exceed limit, returning limit+1.
+func editDistance(a, b []rune, transpose bool, limit int) int {
+	if limit < 0 {
+		limit = max(len(a), len(b))
+	}
+	if len(a)-len(b) > limit || len(b)-len(a) > limit {
+		return limit + 1
+	}
+
+	before, prev, curr := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d = min(d, before[j-2]+1)
+			}
+			curr[j] = d
+			rowMin = min(rowMin, d)
+		}
+
+		// No later row can be less than this row's least
+		if rowMin > limit {
+			return limit + 1
+		}
+		before, prev, curr = prev, curr, before
+	}
+	return min(prev[len(b)], limit+1)
+}
+
+// editSimilarity scales a DamerauLevenshtein distance to 0 through 1
+func editSimilarity(distance, aLen, bLen int) float64 {
+	longest := max(aLen, bLen)
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(distance)/float64(longest)
+}
+
+/*
+JaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 to 1.
+It favors strings that share a beginning, so it suits short strings like
+names: "MARTHA" and "MARHTA" => 0.961. Runes are compared exactly; fold
+case and accents first if they shouldn't count.
+*/
+func JaroWinkler(a, b string) float64 {
+	x, y := []rune(a), []rune(b)
+	jaro := jaro(x, y)
+	if jaro <= 0.7 {
+		return jaro
+	}
+
+	prefix := 0
+	for prefix < min(4, len(x), len(y)) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// jaro returns the Jaro similarity of x and y, which JaroWinkler raises
+// for a shared prefix: "MARTHA" and "MARHTA" => 0.944
+func jaro(x, y []rune) float64 {
+	if len(x) == 0 && len(y) == 0 {
+		return 1
+	}
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+
+	// Runes match if equal and no further apart than the window
+	window := max(max(len(x), len(y))/2-1, 0)
+	xMatched := make([]bool, len(x))
+	yMatched := make([]bool, len(y))
+	matches := 0
+	for i := range x {
+		for j := max(0, i-window); j < min(len(y), i+window+1); j++ {
+			if !yMatched[j] && x[i] == y[j] {
+				xMatched[i], yMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Matched runes that are out of order; each pair is one transposition
+	transpositions, j := 0, 0
+	for i := range x {
+		if !xMatched[i] {
+			continue
+		}
+		for !yMatched[j] {
+			j++
+		}
+		if x[i] != y[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(x)) + m/float64(len(y)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// TrigramDice returns the Dice coefficient of the three-rune sequences of
+// a and b, from 0 to 1. Trigrams don't care about word order, so "Finance
+// Department" scores well against "Department of Finance".
+func TrigramDice(a, b string) float64 {
+	x, y := trigrams(a), trigrams(b)
+	if len(x)+len(y) == 0 {
+		return 1
+	}
+	return 2 * float64(sharedTrigrams(x, y)) / float64(len(x)+len(y))
+}
+
+// TrigramJaccard returns the Jaccard index of the three-rune sequences of
+// a and b: those shared over those in either, from 0 to 1
+func TrigramJaccard(a, b string) float64 {
+	x, y := trigrams(a), trigrams(b)
+	shared := sharedTrigrams(x, y)
+	if union := len(x) + len(y) - shared; union > 0 {
+		return float64(shared) / float64(union)
+	}
+	return 1
+}
+
+// trigrams returns the set of three-rune sequences of *s*, padded so the
+// first and last runes count too: "cat" => "  c", " ca", "cat", "at "
+func trigrams(s string) map[string]bool {
+	if s == "" {
+		return nil
+	}
+
+	r := []rune("  " + s + " ")
+	set := make(map[string]bool, len(r)-2)
+	for i := 0; i+3 <= len(r); i++ {
+		set[string(r[i:i+3])] = true
+	}
+	return set
+}
+
+func sharedTrigrams(x, y map[string]bool) int {
+	if len(x) > len(y) {
+		x, y = y, x
+	}
+	shared := 0
+	for t := range x {
+		if y[t] {
+			shared++
+		}
+	}
+	return shared
+}
+
+// fuzzyFold lower-cases, folds accents and collapses spaces for FuzzyFind
+func fuzzyFold(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(ReplaceAccents(s))), " ")
+}
+
+/*
+FuzzyFind returns the *k* candidates most like *query*, best first, for
+typo-tolerant lookups such as matching a typed department name to the
+canonical list. Case, accents and extra spaces are ignored. A candidate
+scores the better of its DamerauLevenshtein similarity (1 less the edits
+over the longer length) and its TrigramDice, which forgives reordered
+words. Edit distances stop early once a candidate can't beat the current
+k-th best, so long candidate lists stay fast. k 0 or less returns every
+candidate; equal scores keep candidate order.
+*/
+func FuzzyFind(query string, candidates []string, k int) []FuzzyMatch {
+	if k <= 0 || k > len(candidates) {
+		k = len(candidates)
+	}
+	if k == 0 {
+		return nil
+	}
+
+	q := fuzzyFold(query)
+	qRunes, qTrigrams := []rune(q), trigrams(q)
+
+	best := make([]FuzzyMatch, 0, k+1)
+	for i, candidate := range candidates {
+		c := fuzzyFold(candidate)
+		cRunes, cTrigrams := []rune(c), trigrams(c)
+
+		score := 1.0
+		if len(qTrigrams)+len(cTrigrams) > 0 {
+			score = 2 * float64(sharedTrigrams(qTrigrams, cTrigrams)) / float64(len(qTrigrams)+len(cTrigrams))
+		}
+
+		// The edit similarity only matters if it can beat both the
+		// trigram score and, once the list is full, the k-th best
+		floor := score
+		if len(best) == k {
+			floor = max(floor, best[k-1].Score)
+		}
+		longest := max(len(qRunes), len(cRunes))
+		if limit := editLimit(floor, longest); limit >= 0 {
+			distance := editDistance(qRunes, cRunes, true, limit)
+			if distance <= limit {
+				score = max(score, editSimilarity(distance, len(qRunes), len(cRunes)))
+			}
+		}
+
+		if len(best) == k && score <= best[k-1].Score {
+			continue
+		}
+		at := sort.Search(len(best), func(j int) bool { return best[j].Score < score })
+		best = append(best, FuzzyMatch{})
+		copy(best[at+1:], best[at:])
+		best[at] = FuzzyMatch{Candidate: candidate, Index: i, Score: score}
+		if len(best) > k {
+			best = best[:k]
+		}
+	}
+	return best
+}
+
+// editLimit returns the most edits for which strings of *longest* runes
+// have an edit similarity of at least *floor*, or -1 for empty strings.
+// Equal similarities are kept so rounding can't decide between them.
+func editLimit(floor float64, longest int) int {
+	if longest == 0 {
+		return -1
+	}
+	return int((1-floor)*float64(longest) + 1e-9)
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b        string
+		jaro, jaroW float64
+	}{
+		{"MARTHA", "MARHTA", 0.944, 0.961},
+		{"DIXON", "DICKSONX", 0.767, 0.813},
+		{"ABCDEF", "ABCFDE", 0.917, 0.942}, // 3 transpositions
+		{"", "", 1, 1},
+		{"ABC", "", 0, 0},
+		{"ABC", "XYZ", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := jaro([]rune(tt.a), []rune(tt.b)); math.Abs(got-tt.jaro) > 0.0005 {
+			t.Errorf("jaro(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.jaro)
+		}
+		if got := JaroWinkler(tt.a, tt.b); math.Abs(got-tt.jaroW) > 0.0005 {
+			t.Errorf("JaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.jaroW)
+		}
+	}
+}
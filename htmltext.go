@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlSkipped elements hold no readable text
+var htmlSkipped = map[atom.Atom]bool{
+	atom.Head: true, atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Svg: true, atom.Canvas: true,
+	atom.Select: true, atom.Button: true,
+}
+
+// htmlParagraphs are set off by blank lines; htmlLines start on a new line
+var htmlParagraphs = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true,
+	atom.H6: true, atom.Blockquote: true, atom.Pre: true, atom.Table: true, atom.Ul: true,
+	atom.Ol: true, atom.Dl: true, atom.Figure: true, atom.Hr: true,
+}
+
+var htmlLines = map[atom.Atom]bool{
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Nav: true, atom.Aside: true, atom.Main: true, atom.Address: true, atom.Form: true,
+	atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Tr: true, atom.Caption: true,
+	atom.Figcaption: true, atom.Fieldset: true, atom.Details: true, atom.Summary: true,
+}
+
+/*
+HTMLToText extracts readable plain text from an HTML document or
+fragment, for emails and search. Paragraphs, headings and lists are set
+off by blank lines; list items get "- " or "1. " and are indented when
+nested; table rows go on their own lines with cells joined by " | "; and
+links are followed by their URL in brackets, "site [https://...]",
+unless the text already is the URL. Scripts, styles and other
+non-text elements are dropped and runs of spaces collapsed, except in
+<pre>.
+*/
+func HTMLToText(s string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(s))
+	if err != nil {
+		return ""
+	}
+
+	w := &textWriter{}
+	for _, n := range doc.Nodes {
+		w.walk(n)
+	}
+	return w.String()
+}
+
+// textWriter accumulates HTMLToText's output, holding back spaces and
+// line breaks until text follows them
+type textWriter struct {
+	b         strings.Builder
+	breaks    int   // line breaks wanted before the next text
+	space     bool  // a space is wanted before the next text
+	lineStart bool  // the last thing written ended a line
+	pre       int   // depth inside <pre>
+	lists     []int // per open list, 0 for bullets or the next number
+	firstCell bool  // the next cell starts a table row
+}
+
+var htmlSpaces = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			w.walk(c)
+		}
+		return
+	}
+
+	if htmlSkipped[n.DataAtom] {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		// <br><br> makes a blank line, but no more
+		w.breaks = min(w.breaks+1, 2)
+		return
+	case atom.Hr:
+		w.lineBreak(2)
+		w.write("---")
+		w.lineBreak(2)
+		return
+	case atom.Img:
+		return
+	}
+
+	before, after := 0, 0
+	switch {
+	case htmlParagraphs[n.DataAtom]:
+		before, after = 2, 2
+	case htmlLines[n.DataAtom]:
+		before, after = 1, 1
+	}
+	// Nested lists sit under their item, not a blank line away
+	if (n.DataAtom == atom.Ul || n.DataAtom == atom.Ol) && len(w.lists) > 0 {
+		before, after = 1, 1
+	}
+	w.lineBreak(before)
+
+	switch n.DataAtom {
+	case atom.Pre:
+		w.pre++
+		defer func() { w.pre-- }()
+	case atom.Ul:
+		w.lists = append(w.lists, 0)
+		defer func() { w.lists = w.lists[:len(w.lists)-1] }()
+	case atom.Ol:
+		w.lists = append(w.lists, 1)
+		defer func() { w.lists = w.lists[:len(w.lists)-1] }()
+	case atom.Li:
+		w.listItem()
+	case atom.Tr:
+		w.firstCell = true
+	case atom.Td, atom.Th:
+		if !w.firstCell {
+			w.write(" | ")
+		}
+		w.firstCell = false
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+
+	if n.DataAtom == atom.A {
+		w.link(n)
+	}
+	w.lineBreak(after)
+}
+
+// listItem writes the bullet or number for an <li>, indented for its
+// depth
+func (w *textWriter) listItem() {
+	depth := len(w.lists)
+	if depth == 0 {
+		w.write("- ")
+		return
+	}
+
+	indent := strings.Repeat("  ", depth-1)
+	if n := w.lists[depth-1]; n > 0 {
+		w.write(fmt.Sprintf("%s%d. ", indent, n))
+		w.lists[depth-1]++
+	} else {
+		w.write(indent + "- ")
+	}
+}
+
+// link adds a link's URL after its text, unless it adds nothing
+func (w *textWriter) link(n *html.Node) {
+	href := strings.TrimSpace(htmlAttribute(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return
+	}
+
+	text := strings.TrimSpace(htmlSpaces.ReplaceAllString(goquery.NewDocumentFromNode(n).Text(), " "))
+	shown := strings.TrimPrefix(href, "mailto:")
+	if text == href || text == shown || text == "" {
+		if text == "" {
+			w.write(shown)
+		}
+		return
+	}
+	w.write(" [" + shown + "]")
+}
+
+// text writes a text node, collapsing spaces outside <pre>
+func (w *textWriter) text(s string) {
+	if w.pre > 0 {
+		w.write(s)
+		return
+	}
+
+	s = htmlSpaces.ReplaceAllString(s, " ")
+	if s == " " || s == "" {
+		w.space = true
+		return
+	}
+	if strings.HasPrefix(s, " ") {
+		w.space = true
+	}
+	trailing := strings.HasSuffix(s, " ")
+	w.write(strings.TrimSpace(s))
+	w.space = trailing
+}
+
+// lineBreak asks for *n* line breaks (2 for a blank line) before the next
+// text
+func (w *textWriter) lineBreak(n int) {
+	if n > w.breaks {
+		w.breaks = n
+	}
+}
+
+func (w *textWriter) flushBreaks() {
+	if w.b.Len() > 0 && w.breaks > 0 {
+		w.b.WriteString(strings.Repeat("\n", w.breaks))
+		w.lineStart = true
+	}
+	w.breaks = 0
+}
+
+// write adds text after any pending breaks or space
+func (w *textWriter) write(s string) {
+	if s == "" {
+		return
+	}
+	if w.breaks > 0 {
+		w.flushBreaks()
+		w.space = false
+	}
+	if w.space && w.b.Len() > 0 && !w.lineStart {
+		w.b.WriteByte(' ')
+	}
+	w.space = false
+	w.b.WriteString(s)
+	w.lineStart = strings.HasSuffix(s, "\n")
+}
+
+// String returns the text with trailing spaces trimmed from each line
+func (w *textWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// htmlAttribute returns the value of the attribute *key* of *n*
+func htmlAttribute(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
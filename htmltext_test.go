@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"a<br>b", "a\nb"},
+		{"<h1>Title</h1><p>Para  one\n  wraps</p><script>x</script><style>y</style>", "Title\n\nPara one wraps"},
+		{"<p>Tom &amp; Jerry</p>", "Tom & Jerry"},
+
+		// lists, nested lists indented
+		{"<ul><li>one</li><li>two<ol><li>a</li><li>b</li></ol></li></ul><p>after</p>", "- one\n- two\n  1. a\n  2. b\n\nafter"},
+
+		// tables
+		{"<table><tr><th>Name</th><th>Room</th></tr><tr><td>Ann</td><td>101</td></tr></table>", "Name | Room\nAnn | 101"},
+
+		// links, unless the text is the URL
+		{`<p>See <a href="https://example.com">the site</a> or <a href="https://x.org">https://x.org</a>.</p>`, "See the site [https://example.com] or https://x.org."},
+
+		// pre keeps its spacing
+		{"<p>code:</p><pre>  a  b\n    c</pre><p>x   y</p>", "code:\n\n  a  b\n    c\n\nx y"},
+	}
+	for _, tt := range tests {
+		if got := HTMLToText(tt.in); got != tt.want {
+			t.Errorf("HTMLToText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// inflectionRule rewrites the end of a lower-case word
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// inflections holds the rules for Pluralize and Singularize. Rules are
+// tried newest first, so ones added later override the defaults.
+var inflections = struct {
+	sync.RWMutex
+	plural, singular []inflectionRule
+	irregular        map[string]string // singular => plural
+	irregularPlural  map[string]string // plural => singular
+	uncountable      map[string]bool
+}{
+	irregular:       make(map[string]string),
+	irregularPlural: make(map[string]string),
+	uncountable:     make(map[string]bool),
+}
+
+func init() {
+	for _, r := range [][2]string{
+		{`$`, "s"},
+		{`s$`, "s"},
+		{`^(ax|test)is$`, "${1}es"},
+		{`(octop|vir)us$`, "${1}i"},
+		{`(octop|vir)i$`, "${1}i"},
+		{`(alias|status|campus|census|virus|bonus)$`, "${1}es"},
+		{`(bu)s$`, "${1}ses"},
+		{`(buffal|tomat|potat|her|ech|vet)o$`, "${1}oes"},
+		{`([ti])um$`, "${1}a"},
+		{`([ti])a$`, "${1}a"},
+		{`sis$`, "ses"},
+		{`(?:([^f])fe|([lr])f)$`, "${1}${2}ves"},
+		{`(hive)$`, "${1}s"},
+		{`([^aeiouy]|qu)y$`, "${1}ies"},
+		{`(x|ch|ss|sh|zz)$`, "${1}es"},
+		{`(matr|vert|ind)(?:ix|ex)$`, "${1}ices"},
+		{`^(m|l)ouse$`, "${1}ice"},
+		{`^(m|l)ice$`, "${1}ice"},
+		{`^(ox)$`, "${1}en"},
+		{`^(oxen)$`, "${1}"},
+		{`(quiz)$`, "${1}zes"},
+	} {
+		AddPluralRule(r[0], r[1])
+	}
+
+	for _, r := range [][2]string{
+		{`s$`, ""},
+		{`(ss)$`, "${1}"},
+		{`(n)ews$`, "${1}ews"},
+		{`([ti])a$`, "${1}um"},
+		{`((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he|(h)ypothe|(c)ri)(sis|ses)$`, "${1}sis"},
+		{`(^analy)(sis|ses)$`, "${1}sis"},
+		{`([^f])ves$`, "${1}fe"},
+		{`(hive)s$`, "${1}"},
+		{`(tive)s$`, "${1}"},
+		{`([lr])ves$`, "${1}f"},
+		{`([^aeiouy]|qu)ies$`, "${1}y"},
+		{`(m)ovies$`, "${1}ovie"},
+		{`(x|ch|ss|sh|zz)es$`, "${1}"},
+		{`^(m|l)ice$`, "${1}ouse"},
+		{`(bus)(es)?$`, "${1}"},
+		{`(o)es$`, "${1}"},
+		{`(shoe)s$`, "${1}"},
+		{`(cris|test)(is|es)$`, "${1}is"},
+		{`^(a)x[ie]s$`, "${1}xis"},
+		{`(octop|vir)(us|i)$`, "${1}us"},
+		{`(alias|status|campus|census|bonus)(es)?$`, "${1}"},
+		{`^(ox)en`, "${1}"},
+		{`(vert|ind)ices$`, "${1}ex"},
+		{`(matr)ices$`, "${1}ix"},
+		{`(quiz)zes$`, "${1}"},
+		{`(database)s$`, "${1}"},
+	} {
+		AddSingularRule(r[0], r[1])
+	}
+
+	for _, pair := range [][2]string{
+		{"person", "people"}, {"man", "men"}, {"woman", "women"}, {"child", "children"},
+		{"tooth", "teeth"}, {"foot", "feet"}, {"goose", "geese"}, {"move", "moves"},
+		{"criterion", "criteria"}, {"phenomenon", "phenomena"}, {"alumnus", "alumni"},
+		{"alumna", "alumnae"}, {"cactus", "cacti"}, {"syllabus", "syllabi"}, {"focus", "foci"},
+		{"stimulus", "stimuli"}, {"curriculum", "curricula"}, {"appendix", "appendices"},
+		{"index", "indices"}, {"genus", "genera"}, {"corpus", "corpora"}, {"opus", "opera"},
+		{"die", "dice"}, {"leaf", "leaves"}, {"thief", "thieves"}, {"chief", "chiefs"},
+	} {
+		AddIrregular(pair[0], pair[1])
+	}
+
+	for _, word := range []string{
+		"advice", "aircraft", "deer", "equipment", "feedback", "fish", "furniture", "homework",
+		"information", "jeans", "knowledge", "luggage", "metadata", "money", "news", "offspring",
+		"police", "research", "rice", "series", "sheep", "software", "species", "staff",
+	} {
+		AddUncountable(word)
+	}
+}
+
+// AddPluralRule adds a rule for Pluralize: words matching the regular
+// expression *pattern* (lower case, e.g. `(ox)$`) are rewritten with
+// *replacement*, which can refer to groups as ${1}. It panics if the
+// pattern doesn't compile, like regexp.MustCompile.
+func AddPluralRule(pattern, replacement string) {
+	rule := inflectionRule{regexp.MustCompile(pattern), replacement}
+
+	inflections.Lock()
+	defer inflections.Unlock()
+	inflections.plural = append(inflections.plural, rule)
+}
+
+// AddSingularRule adds a rule for Singularize; see AddPluralRule
+func AddSingularRule(pattern, replacement string) {
+	rule := inflectionRule{regexp.MustCompile(pattern), replacement}
+
+	inflections.Lock()
+	defer inflections.Unlock()
+	inflections.singular = append(inflections.singular, rule)
+}
+
+// AddIrregular adds a word whose plural follows no rule, such as
+// "person" and "people"
+func AddIrregular(singular, plural string) {
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+
+	inflections.Lock()
+	defer inflections.Unlock()
+	inflections.irregular[singular] = plural
+	inflections.irregularPlural[plural] = singular
+}
+
+// AddUncountable adds a word with no plural, such as "equipment"
+func AddUncountable(word string) {
+	inflections.Lock()
+	defer inflections.Unlock()
+	inflections.uncountable[strings.ToLower(word)] = true
+}
+
+/*
+Pluralize returns *word* in the plural unless *n* is 1 or -1:
+Pluralize("seminar", 3) => "seminars", Pluralize("person", 2) =>
+"people", Pluralize("equipment", 2) => "equipment". Only the last word of
+a phrase changes ("teaching assistants"), and capitals are kept
+("Person" => "People", "CHILD" => "CHILDREN").
+*/
+func Pluralize(word string, n int) string {
+	if n == 1 || n == -1 {
+		return word
+	}
+	return inflect(word, true)
+}
+
+// Singularize returns *word* in the singular: "seminars" => "seminar",
+// "people" => "person", "analyses" => "analysis"; see Pluralize
+func Singularize(word string) string {
+	return inflect(word, false)
+}
+
+// inflect changes the last word of *phrase* to the plural or singular
+func inflect(phrase string, plural bool) string {
+	start := strings.LastIndexAny(phrase, " -_") + 1
+	prefix, word := phrase[:start], phrase[start:]
+	lower := strings.ToLower(word)
+	if lower == "" {
+		return phrase
+	}
+
+	inflections.RLock()
+	defer inflections.RUnlock()
+
+	if inflections.uncountable[lower] {
+		return phrase
+	}
+
+	irregular, already, rules := inflections.irregular, inflections.irregularPlural, inflections.plural
+	if !plural {
+		irregular, already, rules = inflections.irregularPlural, inflections.irregular, inflections.singular
+	}
+
+	result, ok := irregular[lower]
+	if !ok {
+		if _, ok := already[lower]; ok {
+			return phrase
+		}
+		result = lower
+		for i := len(rules) - 1; i >= 0; i-- {
+			if rules[i].pattern.MatchString(lower) {
+				result = rules[i].pattern.ReplaceAllString(lower, rules[i].replacement)
+				break
+			}
+		}
+	}
+	return prefix + inflectCase(word, result)
+}
+
+// inflectCase gives *result* the capitals of *word*, which it is a form of
+func inflectCase(word, result string) string {
+	if len(word) > 1 && word == strings.ToUpper(word) {
+		return strings.ToUpper(result)
+	}
+
+	// Keep the letters they share as written, e.g. "PhD" => "PhDs". Go
+	// rune by rune: lower-casing can change a rune's length, and "İ"
+	// becomes two runes.
+	kept, matched := 0, 0
+	for kept < len(word) {
+		_, size := utf8.DecodeRuneInString(word[kept:])
+		lower := strings.ToLower(word[kept : kept+size])
+		if !strings.HasPrefix(result[matched:], lower) {
+			break
+		}
+		kept, matched = kept+size, matched+len(lower)
+	}
+	return word[:kept] + result[matched:]
+}
+
+// CountPhrase returns *n* and *noun* made to agree, for templates: "no
+// seminars", "1 seminar", "1,250 seminars"
+func CountPhrase(n int, noun string) string {
+	if n == 0 {
+		return "no " + Pluralize(noun, 0)
+	}
+	return fmt.Sprintf("%s %s", FormatCommas(strconv.Itoa(n)), Pluralize(noun, n))
+}
+
+// CountList is CountPhrase followed by *items* joined with Conjoin:
+// CountList("speaker", []string{"Ann", "Bo", "Cy"}, "and") => "3
+// speakers: Ann, Bo, and Cy", and no items gives "no speakers"
+func CountList(noun string, items []string, conjunction string) string {
+	phrase := CountPhrase(len(items), noun)
+	if len(items) == 0 {
+		return phrase
+	}
+	return phrase + ": " + Conjoin(items, conjunction)
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		word string
+		n    int
+		want string
+	}{
+		{"seminar", 2, "seminars"},
+		{"seminar", 1, "seminar"},
+		{"PhD", 2, "PhDs"},
+		{"CITY", 2, "CITIES"},
+		{"City", 2, "Cities"},
+		{"bİ", 2, "bİs"},
+		{"İb", 2, "İbs"},
+	}
+	for _, tt := range tests {
+		got := Pluralize(tt.word, tt.n)
+		if !utf8.ValidString(got) {
+			t.Errorf("Pluralize(%q, %d) = %q, not valid UTF-8", tt.word, tt.n, got)
+		}
+		if got != tt.want {
+			t.Errorf("Pluralize(%q, %d) = %q, want %q", tt.word, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISOWeek identifies an ISO 8601 week, e.g. 2026-W42.
+// Note that the ISO year can differ from the calendar year
+// for days at the very start or end of December/January.
+type ISOWeek struct {
+	Year int
+	Week int
+}
+
+// ISOWeekOf returns the ISO week containing t
+func ISOWeekOf(t time.Time) ISOWeek {
+	year, week := t.ISOWeek()
+	return ISOWeek{Year: year, Week: week}
+}
+
+// ISOWeeksInYear returns the number of ISO weeks (52 or 53) in *year*.
+// A year has 53 weeks when it starts on a Thursday, or when it is a
+// leap year starting on a Wednesday.
+func ISOWeeksInYear(year int) int {
+	// p returns the weekday (0 = Sunday) of Dec 31 of year y
+	p := func(y int) int {
+		return ((y+floorDiv(y, 4)-floorDiv(y, 100)+floorDiv(y, 400))%7 + 7) % 7
+	}
+
+	if p(year) == 4 || p(year-1) == 3 {
+		return 53
+	}
+	return 52
+}
+
+// Valid reports whether the week exists in its ISO year
+func (w ISOWeek) Valid() bool {
+	return w.Week >= 1 && w.Week <= ISOWeeksInYear(w.Year)
+}
+
+// String formats the week as yyyy-Www, e.g. 2026-W42
+func (w ISOWeek) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// Start returns 12AM on the Monday of the week in *loc*
+func (w ISOWeek) Start(loc *time.Location) time.Time {
+	// January 4 is always in week 1, so step back to its Monday
+	// and then forward by whole weeks.
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+
+	return time.Date(w.Year, time.January, 4-offset+(w.Week-1)*7, 0, 0, 0, 0, loc)
+}
+
+// End returns the end of the Sunday of the week in *loc*
+// (see EndOfDay).
+func (w ISOWeek) End(loc *time.Location) time.Time {
+	return EndOfDay(w.Start(loc).AddDate(0, 0, 6))
+}
+
+// Day returns 12AM on *weekday* of the week in *loc*
+func (w ISOWeek) Day(weekday time.Weekday, loc *time.Location) time.Time {
+	return w.Start(loc).AddDate(0, 0, (int(weekday)+6)%7)
+}
+
+// Next returns the following ISO week, rolling into the next ISO year
+// as needed.
+func (w ISOWeek) Next() ISOWeek {
+	if w.Week >= ISOWeeksInYear(w.Year) {
+		return ISOWeek{Year: w.Year + 1, Week: 1}
+	}
+	return ISOWeek{Year: w.Year, Week: w.Week + 1}
+}
+
+// Prev returns the preceding ISO week, rolling into the previous ISO year
+// as needed.
+func (w ISOWeek) Prev() ISOWeek {
+	if w.Week <= 1 {
+		return ISOWeek{Year: w.Year - 1, Week: ISOWeeksInYear(w.Year - 1)}
+	}
+	return ISOWeek{Year: w.Year, Week: w.Week - 1}
+}
+
+// Before reports whether w comes before *other*
+func (w ISOWeek) Before(other ISOWeek) bool {
+	if w.Year != other.Year {
+		return w.Year < other.Year
+	}
+	return w.Week < other.Week
+}
+
+// WeekRange returns the start (Monday 12AM) and end (Sunday, see EndOfDay)
+// of the given ISO week in *loc*.
+func WeekRange(year int, week int, loc *time.Location) (start time.Time, end time.Time) {
+	w := ISOWeek{Year: year, Week: week}
+	return w.Start(loc), w.End(loc)
+}
+
+// ISOWeeksBetween returns every ISO week from *from* through *to* inclusive.
+// The result is empty if *to* is before *from*.
+func ISOWeeksBetween(from ISOWeek, to ISOWeek) (result []ISOWeek) {
+	for w := from; !to.Before(w); w = w.Next() {
+		result = append(result, w)
+	}
+	return result
+}
+
+// ParseISOWeek parses yyyy-Www (or the compact yyyyWww)
+func ParseISOWeek(candidate string) (ISOWeek, error) {
+	w, weekday, err := parseISOWeekDate(candidate)
+	if err != nil {
+		return ISOWeek{}, err
+	}
+	if weekday != 0 {
+		return ISOWeek{}, fmt.Errorf(`iso week "%s" must not include a day`, candidate)
+	}
+	return w, nil
+}
+
+// ParseISOWeekDate parses yyyy-Www-d (or the compact yyyyWwwd) and returns
+// 12AM of that day in *loc*. If the day is omitted (yyyy-Www), Monday is
+// assumed. Days run 1 (Monday) through 7 (Sunday).
+func ParseISOWeekDate(candidate string, loc *time.Location) (time.Time, error) {
+	w, weekday, err := parseISOWeekDate(candidate)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if weekday == 0 {
+		weekday = 1
+	}
+	return w.Start(loc).AddDate(0, 0, weekday-1), nil
+}
+
+// FormatISOWeek formats t's ISO week as yyyy-Www, e.g. 2026-W42
+func FormatISOWeek(t time.Time) string {
+	return ISOWeekOf(t).String()
+}
+
+// FormatISOWeekDate formats t as yyyy-Www-d, e.g. 2026-W42-3
+func FormatISOWeekDate(t time.Time) string {
+	return fmt.Sprintf("%s-%d", ISOWeekOf(t), (int(t.Weekday())+6)%7+1)
+}
+
+// parseISOWeekDate returns the week and the ISO day number (1-7),
+// or 0 if no day was supplied.
+func parseISOWeekDate(candidate string) (ISOWeek, int, error) {
+	s := strings.ToUpper(strings.TrimSpace(candidate))
+	badFormat := fmt.Errorf(`iso week date "%s" not in right format`, candidate)
+
+	wIndex := strings.Index(s, "W")
+	if wIndex < 4 {
+		return ISOWeek{}, 0, badFormat
+	}
+
+	// The separators are all or nothing: 2026-W42-3 or 2026W423
+	extended := strings.HasSuffix(s[:wIndex], "-")
+	yearPart := strings.TrimSuffix(s[:wIndex], "-")
+	rest := s[wIndex+1:]
+	dayPart := ""
+
+	switch {
+	case len(rest) == 2:
+	case len(rest) == 4 && rest[2] == '-' && extended:
+		dayPart = rest[3:]
+	case len(rest) == 3 && !extended:
+		dayPart = rest[2:]
+	default:
+		return ISOWeek{}, 0, badFormat
+	}
+
+	// strconv.Atoi accepts signs, so check the digits ourselves
+	if !isASCIIDigits(yearPart, 4) || !isASCIIDigits(rest[:2], 2) ||
+		(dayPart != "" && !isASCIIDigits(dayPart, 1)) {
+		return ISOWeek{}, 0, badFormat
+	}
+
+	year, _ := strconv.Atoi(yearPart)
+	week, _ := strconv.Atoi(rest[:2])
+
+	w := ISOWeek{Year: year, Week: week}
+	if !w.Valid() {
+		return ISOWeek{}, 0, fmt.Errorf("%d has no ISO week %d", year, week)
+	}
+
+	day := 0
+	if dayPart != "" {
+		day, _ = strconv.Atoi(dayPart)
+		if day < 1 || day > 7 {
+			return ISOWeek{}, 0, badFormat
+		}
+	}
+
+	return w, day, nil
+}
+
+// isASCIIDigits reports whether s is exactly *width* ASCII digits
+func isASCIIDigits(s string, width int) bool {
+	if len(s) != width {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// floorDiv divides rounding toward negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
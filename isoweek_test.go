@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISOWeekDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2026-W42", "2026-10-12"},
+		{"2026-W42-3", "2026-10-14"},
+		{"2026W423", "2026-10-14"},
+		{" 2026-w01-7 ", "2026-01-04"},
+		{"2020-W53-5", "2021-01-01"},
+		{"2009-W01-1", "2008-12-29"},
+	}
+	for _, tt := range tests {
+		got, err := ParseISOWeekDate(tt.in, time.UTC)
+		if err != nil {
+			t.Errorf("ParseISOWeekDate(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("ParseISOWeekDate(%q) = %s, want %s", tt.in, got.Format("2006-01-02"), tt.want)
+		}
+	}
+}
+
+func TestParseISOWeekDateRejects(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"+026-W42",
+		"-026-W42",
+		"2026-W+1",
+		"2026-W-1",
+		"2026-W4",
+		"2026-W042",
+		"2026-W42-+",
+		"2026-W42-0",
+		"2026-W42-8",
+		"2026-W42-",
+		"2026W42-3",
+		"2026-W423",
+		"26-W42",
+		"20266-W42",
+		"2025-W53",
+		"2026-W00",
+		"2026-W４２",
+	} {
+		if got, err := ParseISOWeekDate(in, time.UTC); err == nil {
+			t.Errorf("ParseISOWeekDate(%q) = %v, want error", in, got)
+		}
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	w, err := ParseISOWeek("2026-W42")
+	if err != nil || w != (ISOWeek{Year: 2026, Week: 42}) {
+		t.Errorf(`ParseISOWeek("2026-W42") = %v, %v`, w, err)
+	}
+	if _, err := ParseISOWeek("2026-W42-1"); err == nil {
+		t.Error(`ParseISOWeek("2026-W42-1") accepted a day`)
+	}
+	if got := w.String(); got != "2026-W42" {
+		t.Errorf("String() = %q", got)
+	}
+}
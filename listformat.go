@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// listLocale is how a language joins lists
+type listLocale struct {
+	and, or       string
+	oxfordComma   bool
+	other, others string // "1 other", "4 others"
+}
+
+var listLocales = map[string]listLocale{
+	"en": {"and", "or", true, "other", "others"},
+	"es": {"y", "o", false, "más", "más"},
+	"fr": {"et", "ou", false, "autre", "autres"},
+	"de": {"und", "oder", false, "weitere", "weitere"},
+	"it": {"e", "o", false, "altro", "altri"},
+	"pt": {"e", "ou", false, "outro", "outros"},
+}
+
+/*
+ListFormatter joins items into a phrase such as "Ann, Bo, and Cy". The
+zero value formats like Conjoin with "and". For example,
+
+	ListFormatter{MaxItems: 2}.Format(names)
+
+gives "Ann, Bo, and 4 others" for six names, and with Locale "es",
+"Ann, Bo y 4 más".
+*/
+type ListFormatter struct {
+	// Locale picks the conjunctions, the Oxford comma and the "others"
+	// phrase: "en" (the default), "es", "fr", "de", "it" or "pt"
+	Locale string
+
+	// Or joins with the locale's "or" instead of its "and"
+	Or bool
+
+	// Conjunction overrides the locale's word, e.g. "and/or"
+	Conjunction string
+
+	// Separator goes between items; "" means ", "
+	Separator string
+
+	// NoOxfordComma leaves out the separator before the conjunction in
+	// lists of three or more, which only English uses
+	NoOxfordComma bool
+
+	// MaxItems shows at most this many items and sums up the rest, as in
+	// "and 4 others"; 0 shows all
+	MaxItems int
+
+	// Others overrides the locale's phrase for the *n* items not shown
+	Others func(n int) string
+
+	// HTML escapes the items and everything added between them
+	HTML bool
+}
+
+// Format joins *items*
+func (f ListFormatter) Format(items []string) string {
+	return f.FormatFunc(len(items), func(i int) string { return items[i] })
+}
+
+// FormatStringers joins *items* by their String methods
+func (f ListFormatter) FormatStringers(items []fmt.Stringer) string {
+	return f.FormatFunc(len(items), func(i int) string { return items[i].String() })
+}
+
+// FormatFunc joins *n* items named by *item*, so any slice can be listed
+// without first copying it into strings:
+//
+//	f.FormatFunc(len(people), func(i int) string { return people[i].FullName })
+func (f ListFormatter) FormatFunc(n int, item func(i int) string) string {
+	locale, ok := listLocales[strings.ToLower(f.Locale)]
+	if !ok {
+		locale = listLocales["en"]
+	}
+
+	conjunction := f.Conjunction
+	if conjunction == "" {
+		conjunction = locale.and
+		if f.Or {
+			conjunction = locale.or
+		}
+	}
+	separator := f.Separator
+	if separator == "" {
+		separator = ", "
+	}
+	escape := func(s string) string {
+		if f.HTML {
+			return html.EscapeString(s)
+		}
+		return s
+	}
+
+	shown := n
+	if f.MaxItems > 0 && n > f.MaxItems {
+		shown = f.MaxItems
+	}
+	parts := make([]string, 0, shown+1)
+	for i := 0; i < shown; i++ {
+		parts = append(parts, escape(item(i)))
+	}
+	if shown < n {
+		rest := n - shown
+		var others string
+		switch {
+		case f.Others != nil:
+			others = f.Others(rest)
+		case rest == 1:
+			others = fmt.Sprintf("1 %s", locale.other)
+		default:
+			others = fmt.Sprintf("%d %s", rest, locale.others)
+		}
+		parts = append(parts, escape(others))
+	}
+
+	last := len(parts) - 1
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	case 2:
+		return parts[0] + " " + escape(conjunction) + " " + parts[1]
+	}
+
+	before := " "
+	if locale.oxfordComma && !f.NoOxfordComma {
+		before = strings.TrimRight(separator, " ") + " "
+	}
+	return strings.Join(parts[:last], escape(separator)) + escape(before+conjunction+" ") + parts[last]
+}
@@ -6,6 +6,7 @@ import (
 )
 
 type nameString struct {
+	parser    *NameParser
 	FullName  string
 	SplitName []string
 	Nickname  string
@@ -35,10 +36,6 @@ func (n *nameString) searchParts(parts []string) int {
 	return -1
 }
 
-func (n *nameString) looksCorporate() bool {
-	return n.searchParts(corpEntity) > -1
-}
-
 func (n *nameString) hasComma() bool {
 	for _, x := range n.split() {
 		if strings.ContainsAny(x, ",") {
@@ -109,7 +106,7 @@ func (n *nameString) fixMisplacedApostrophe() {
 
 func (n *nameString) hasAliases() (bool, string) {
 	upperName := strings.ToUpper(n.FullName)
-	for _, x := range nonName {
+	for _, x := range n.parser.NonName {
 		if strings.Contains(upperName, x) && !strings.HasSuffix(upperName, x) {
 			return true, x
 		}
@@ -120,23 +117,29 @@ func (n *nameString) hasAliases() (bool, string) {
 func (n *nameString) find(part string) int {
 	switch part {
 	case "salutation":
-		return n.searchParts(salutations)
+		return n.searchParts(n.parser.Salutations)
 	case "generation":
-		return n.searchParts(generations)
+		return n.searchParts(n.parser.Generations)
 	case "suffix":
-		return n.searchParts(suffixes)
+		return n.searchParts(n.parser.Suffixes)
 	case "lnprefix":
-		return n.searchParts(lnPrefixes)
+		return n.searchParts(n.parser.LastNamePrefixes)
 	case "nonname":
-		return n.searchParts(nonName)
+		return n.searchParts(n.parser.NonName)
 	case "supplemental":
-		return n.searchParts(supplementalInfo)
+		return n.searchParts(n.parser.SupplementalInfo)
 	default:
 
 	}
 	return -1
 }
 
+// isGenerationOrSuffix reports whether s starts with a generation or suffix
+func (n *nameString) isGenerationOrSuffix(s string) bool {
+	words := strings.Fields(s)
+	return len(words) > 0 && n.parser.isGenerationOrSuffix(words[0])
+}
+
 func (n *nameString) split() []string {
 
 	n.SplitName = strings.Fields(n.FullName)
@@ -163,11 +166,16 @@ func (n *nameString) normalize() []string {
 	// Handle misplaced apostrophes
 	n.fixMisplacedApostrophe()
 
-	// Swap Lastname, Firstname to Firstname Lastname
+	// Swap Lastname, Firstname to Firstname Lastname, unless the comma
+	// only sets off a generation or suffix (John Smith, Jr.)
 	if n.hasComma() {
 		commaSplit := strings.SplitN(n.FullName, ",", 2)
-		sort.StringSlice(commaSplit).Swap(1, 0)
-		n.FullName = strings.Join(commaSplit, " ")
+		if n.isGenerationOrSuffix(commaSplit[1]) {
+			n.FullName = strings.Join(commaSplit, " ")
+		} else {
+			sort.StringSlice(commaSplit).Swap(1, 0)
+			n.FullName = strings.Join(commaSplit, " ")
+		}
 	}
 
 	return n.cleaned()
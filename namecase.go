@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	// nameCaseExceptions maps lower-cased names to their known spellings
+	nameCaseExceptions = map[string]string{
+		"laguardia": "LaGuardia",
+		"dimaggio":  "DiMaggio",
+		"devito":    "DeVito",
+		"leblanc":   "LeBlanc",
+		"dupont":    "DuPont",
+		"phd":       "PhD",
+		"md":        "MD",
+		"dds":       "DDS",
+		"cpa":       "CPA",
+		"jr":        "Jr",
+		"sr":        "Sr",
+	}
+	nameCaseMu sync.RWMutex
+
+	// macExceptions are names starting with "mac" that aren't Mac-prefixed
+	macExceptions = []string{"mace", "macey", "machado", "machar", "machin", "macias", "maciel", "mack", "mackie",
+		"macklin", "macko", "maclin", "macon", "macy", "macario", "macedo", "machen", "machek", "macri", "mackey"}
+
+	// nameCaseKeepCapital are lnPrefixes that are also common names on
+	// their own, or that conventionally keep a capital (Li, Le Pen, Ben)
+	nameCaseKeepCapital = []string{"MC", "LI", "LE", "BEN", "BIN", "IBN", "SAN", "SAINZ", "TEN"}
+)
+
+// AddNameCaseExceptions registers known spellings that NameCase should
+// reproduce exactly, e.g. AddNameCaseExceptions("DeAndre", "MacArthur")
+func AddNameCaseExceptions(spellings ...string) {
+	nameCaseMu.Lock()
+	defer nameCaseMu.Unlock()
+
+	for _, s := range spellings {
+		nameCaseExceptions[strings.ToLower(s)] = s
+	}
+}
+
+// NameCase capitalizes a name the way it is conventionally written:
+// "MCDONALD" => "McDonald", "O'BRIEN" => "O'Brien", "SMITH-JONES" =>
+// "Smith-Jones", "VAN DER BERG" => "van der Berg", "JOHN SMITH III" =>
+// "John Smith III". Particles from the surname-prefix list are lower-cased
+// unless they end the name, roman-numeral generations are upper-cased,
+// and spellings registered with AddNameCaseExceptions are used as-is.
+func NameCase(name string) string {
+	words := strings.Fields(name)
+
+	for i, word := range words {
+		isLast := i == len(words)-1
+		words[i] = nameCaseWord(word, isLast)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// nameCaseWord capitalizes one space-separated word
+func nameCaseWord(word string, isLast bool) string {
+	bare := strings.ToUpper(strings.Trim(word, ".,"))
+
+	if !isLast && isNameParticle(bare) {
+		return strings.ToLower(word)
+	}
+	if isRomanGeneration(bare) {
+		return strings.ToUpper(word)
+	}
+
+	segments := strings.Split(word, "-")
+	for i, segment := range segments {
+		segments[i] = nameCaseSegment(segment)
+	}
+	return strings.Join(segments, "-")
+}
+
+// nameCaseSegment capitalizes one hyphen-separated part of a word
+func nameCaseSegment(segment string) string {
+	lower := strings.ToLower(segment)
+	trimmed := strings.Trim(lower, ".,")
+
+	nameCaseMu.RLock()
+	known, ok := nameCaseExceptions[trimmed]
+	nameCaseMu.RUnlock()
+	if ok {
+		return strings.Replace(lower, trimmed, known, 1)
+	}
+
+	// Keep any leading quotes or brackets, e.g. a quoted nickname
+	start := strings.IndexFunc(lower, unicode.IsLetter)
+	if start < 0 {
+		return segment
+	}
+	lead, lower := lower[:start], lower[start:]
+
+	runes := []rune(lower)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	switch {
+	// O'Brien, D'Angelo
+	case len(runes) > 2 && (runes[1] == '\'' || runes[1] == '’'):
+		runes[2] = unicode.ToUpper(runes[2])
+	// McDonald
+	case len(runes) > 3 && strings.HasPrefix(trimmed, "mc"):
+		runes[2] = unicode.ToUpper(runes[2])
+	// MacDonald, but not Machado or Mack
+	case len(runes) > 5 && strings.HasPrefix(trimmed, "mac") && !isMacException(trimmed):
+		runes[3] = unicode.ToUpper(runes[3])
+	}
+
+	return lead + string(runes)
+}
+
+func isNameParticle(word string) bool {
+	for _, keep := range nameCaseKeepCapital {
+		if word == keep {
+			return false
+		}
+	}
+	for _, prefix := range lnPrefixes {
+		if word == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func isRomanGeneration(word string) bool {
+	switch word {
+	case "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X":
+		return true
+	}
+	return false
+}
+
+func isMacException(word string) bool {
+	for _, exception := range macExceptions {
+		if word == exception {
+			return true
+		}
+	}
+	return false
+}
+
+// nameCaseParts applies NameCase to each part of a parsed name
+func (p *NameParts) nameCaseParts() {
+	p.Salutation = NameCase(p.Salutation)
+	p.FirstName = NameCase(p.FirstName)
+	p.MiddleName = NameCase(p.MiddleName)
+	p.LastName = NameCase(p.LastName)
+	p.Generation = NameCase(p.Generation)
+	p.Suffix = NameCase(p.Suffix)
+	p.Nickname = NameCase(p.Nickname)
+	p.FormalFirstName = NameCase(p.FormalFirstName)
+	p.PaternalLastName = NameCase(p.PaternalLastName)
+	p.MaternalLastName = NameCase(p.MaternalLastName)
+}
+
+// isSingleCase reports whether s has letters and they are all upper
+// case or all lower case
+func isSingleCase(s string) bool {
+	hasUpper, hasLower := false, false
+	for _, r := range s {
+		hasUpper = hasUpper || unicode.IsUpper(r)
+		hasLower = hasLower || unicode.IsLower(r)
+	}
+	return hasUpper != hasLower
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNameCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"JOHN SMITH", "John Smith"},
+		{"MCDONALD", "McDonald"},
+		{"mcdonald", "McDonald"},
+		{"MACDONALD", "MacDonald"},
+		{"MACHADO", "Machado"},
+		{"MACK", "Mack"},
+		{"MACY", "Macy"},
+		{"O'BRIEN", "O'Brien"},
+		{"d’angelo", "D’Angelo"},
+		{"SMITH-JONES", "Smith-Jones"},
+		{"O'BRIEN-MCCARTHY", "O'Brien-McCarthy"},
+		{"VAN DER BERG", "van der Berg"},
+		{"LUDWIG VAN BEETHOVEN", "Ludwig van Beethoven"},
+		{"JEAN DE LA FONTAINE", "Jean de la Fontaine"},
+		// a particle that ends the name is the name
+		{"DICK VAN", "Dick Van"},
+		// particles that conventionally keep a capital
+		{"JET LI", "Jet Li"},
+		{"MARINE LE PEN", "Marine Le Pen"},
+		{"JOHN SMITH III", "John Smith III"},
+		{"JOHN SMITH JR.", "John Smith Jr."},
+		{"JANE DOE PHD", "Jane Doe PhD"},
+		{"FIORELLO LAGUARDIA", "Fiorello LaGuardia"},
+		{`ROBERT "BOB" JONES`, `Robert "Bob" Jones`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NameCase(tt.in); got != tt.want {
+			t.Errorf("NameCase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToTitleCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"MARY MCDONALD", "Mary McDonald"},
+		{"LA FITNESS", "la Fitness"},
+		// mixed-case input is left alone
+		{"Mary McDonald", "Mary McDonald"},
+		{"mary smith", "mary smith"},
+	}
+	for _, tt := range tests {
+		if got := ToTitleCase(context.Background(), tt.in); got != tt.want {
+			t.Errorf("ToTitleCase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
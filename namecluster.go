@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PersonRecord is one roster entry for ClusterPeople. ID is the caller's
+// key and is passed through untouched; Email is optional.
+type PersonRecord struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// PersonCluster is a group of records judged to be the same person
+type PersonCluster struct {
+	Records   []PersonRecord `json:"records"`
+	Canonical PersonRecord   `json:"canonical"` // the most complete record
+	Score     float64        `json:"score"`     // lowest score between two of its records
+}
+
+// ClusterOptions configures ClusterPeople
+type ClusterOptions struct {
+	// Threshold is the lowest score allowed between any two records in a
+	// cluster, from 0 to 1; 0 means the default of 0.85
+	Threshold float64
+
+	// Singletons includes records that matched nothing, as clusters of one
+	Singletons bool
+}
+
+// clusterEntry is a PersonRecord prepared for comparison
+type clusterEntry struct {
+	record PersonRecord
+	name   NameParts
+	email  string
+	keys   []string // sorted blocking keys
+}
+
+/*
+ClusterPeople groups records that name the same person, such as "Bill
+Smith", "William J. Smith" and "Smith, W." from merged rosters. Names are
+normalized with ParseName and compared with MatchNames; a shared email
+adds to a pair's score and differing emails subtract from it. Pairs are
+only compared within blocks that share a last name (or its sound) and a
+first initial (or a nickname's), or an email, so large rosters don't
+compare every pair. Clusters are joined only when every record in one
+scores at least the threshold against every record in the other, so "J.
+Smith" can't bridge "John Smith" and "Jane Smith" into one person.
+Clusters come back largest first, each with the most complete record as
+Canonical.
+*/
+func ClusterPeople(records []PersonRecord, opts ClusterOptions) []PersonCluster {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = 0.85
+	}
+
+	parent := make([]int, len(records))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// Identical names (and emails) join at once; only the first of each
+	// is compared with others. members holds each root's records.
+	entries := make([]clusterEntry, len(records))
+	members := make(map[int][]int)
+	blocks := make(map[string][]int)
+	firstOf := make(map[string]int)
+	for i, record := range records {
+		entries[i] = clusterEntry{
+			record: record,
+			name:   ParseName(record.Name),
+			email:  strings.ToLower(strings.TrimSpace(record.Email)),
+		}
+
+		signature := entries[i].signature()
+		if first, ok := firstOf[signature]; ok && signature != "" {
+			parent[i] = first
+			members[first] = append(members[first], i)
+			continue
+		}
+		firstOf[signature] = i
+		members[i] = []int{i}
+
+		entries[i].keys = entries[i].blockingKeys()
+		for _, key := range entries[i].keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	// Visit blocks in a fixed order so the weakest links are repeatable
+	keys := make([]string, 0, len(blocks))
+	for key := range blocks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Link matching pairs within each block. A pair sharing several
+	// blocks is only compared in the first of them. Linking a pair joins
+	// their clusters, so every pair across the two must match too.
+	weakest := make(map[int]float64)
+	for _, key := range keys {
+		block := blocks[key]
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				a, b := entries[block[x]], entries[block[y]]
+				rootX, rootY := find(block[x]), find(block[y])
+				if rootX == rootY || a.shareKeyBefore(b, key) {
+					continue
+				}
+
+				link, ok := linkScore(entries, members[rootX], members[rootY], threshold)
+				if !ok {
+					continue
+				}
+
+				for _, root := range []int{rootX, rootY} {
+					if w, ok := weakest[root]; ok && w < link {
+						link = w
+					}
+				}
+				parent[rootY] = rootX
+				members[rootX] = append(members[rootX], members[rootY]...)
+				delete(members, rootY)
+				delete(weakest, rootY)
+				weakest[rootX] = link
+			}
+		}
+	}
+
+	// Gather clusters in record order
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range entries {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+
+	var result []PersonCluster
+	for _, root := range roots {
+		members := groups[root]
+		if len(members) == 1 && !opts.Singletons {
+			continue
+		}
+
+		cluster := PersonCluster{Score: 1}
+		if w, ok := weakest[root]; ok {
+			cluster.Score = w
+		}
+		best := -1
+		for _, i := range members {
+			cluster.Records = append(cluster.Records, entries[i].record)
+			if best < 0 || entries[i].completeness() > entries[best].completeness() {
+				best = i
+			}
+		}
+		cluster.Canonical = entries[best].record
+		result = append(result, cluster)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i].Records) > len(result[j].Records)
+	})
+	return result
+}
+
+// linkScore returns the lowest score between a record in *x* and one in
+// *y*, and false as soon as a pair falls below *threshold*
+func linkScore(entries []clusterEntry, x, y []int, threshold float64) (float64, bool) {
+	lowest := 1.0
+	for _, i := range x {
+		for _, j := range y {
+			score := entries[i].similarity(entries[j])
+			if score < threshold {
+				return 0, false
+			}
+			lowest = min(lowest, score)
+		}
+	}
+	return lowest, true
+}
+
+// blockingKeys returns the blocks an entry is compared within: last name
+// and its Double Metaphone code, each with the first initial and the
+// initials of its nickname equivalents; the names swapped; and the email
+func (e clusterEntry) blockingKeys() []string {
+	var keys []string
+	if e.email != "" {
+		keys = append(keys, "email:"+e.email)
+	}
+
+	last := foldNamePart(e.name.LastName)
+	first := foldNamePart(e.name.FirstName)
+	if last == "" || first == "" {
+		return keys
+	}
+
+	lastCodes := []string{"name:" + last}
+	if primary, _ := DoubleMetaphone(last); primary != "" {
+		lastCodes = append(lastCodes, "sound:"+primary)
+	}
+
+	initials := []string{initial(first)}
+	for _, equivalent := range GivenNameEquivalents(first) {
+		initials = AppendIfMissing(initials, initial(equivalent))
+	}
+
+	for _, code := range lastCodes {
+		for _, letter := range initials {
+			keys = append(keys, code+"|"+letter)
+		}
+	}
+
+	// First and last names entered in swapped order
+	keys = append(keys, "name:"+first+"|"+initial(last))
+
+	sort.Strings(keys)
+	return keys
+}
+
+// signature identifies entries with the same folded name and email, or
+// is blank for entries with neither
+func (e clusterEntry) signature() string {
+	m := foldForMatch(e.name)
+	if m.first == "" && m.last == "" && e.email == "" {
+		return ""
+	}
+	return strings.Join([]string{m.first, m.middle, m.last, m.generation, e.email}, "|")
+}
+
+// shareKeyBefore reports whether e and other share a blocking key that
+// sorts before *key*
+func (e clusterEntry) shareKeyBefore(other clusterEntry, key string) bool {
+	for _, k := range e.keys {
+		if k >= key {
+			return false
+		}
+		if _, found := slices.BinarySearch(other.keys, k); found {
+			return true
+		}
+	}
+	return false
+}
+
+// similarity scores two entries from 0 to 1
+func (e clusterEntry) similarity(other clusterEntry) float64 {
+	score := MatchNames(e.name, other.name).Score
+
+	switch {
+	case e.email == "" || other.email == "":
+	case e.email == other.email:
+		score += 0.15
+	default:
+		score -= 0.1
+	}
+
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+// completeness ranks records for Canonical: more name parts, full first
+// names over initials and nicknames, and an email
+func (e clusterEntry) completeness() int {
+	score := 0
+	for _, part := range []string{e.name.Salutation, e.name.FirstName, e.name.MiddleName, e.name.LastName, e.name.Generation, e.name.Suffix} {
+		score += min(len([]rune(strings.Trim(part, "."))), 8)
+	}
+	if e.name.FormalFirstName != "" {
+		score -= 2
+	}
+	if e.email != "" {
+		score += 4
+	}
+	return score
+}
@@ -0,0 +1,51 @@
+package utils
+
+import "testing"
+
+func TestClusterPeopleNeedsEveryPairToMatch(t *testing.T) {
+	records := []PersonRecord{
+		{ID: "1", Name: "John Smith"},
+		{ID: "2", Name: "J. Smith"},
+		{ID: "3", Name: "Jane Smith"},
+	}
+
+	clusters := ClusterPeople(records, ClusterOptions{Singletons: true})
+	if len(clusters) != 2 {
+		t.Fatalf("ClusterPeople gave %d clusters, want 2: %+v", len(clusters), clusters)
+	}
+
+	for _, cluster := range clusters {
+		var hasJohn, hasJane bool
+		for _, record := range cluster.Records {
+			hasJohn = hasJohn || record.ID == "1"
+			hasJane = hasJane || record.ID == "3"
+		}
+		if hasJohn && hasJane {
+			t.Errorf("John Smith and Jane Smith share a cluster: %+v", cluster)
+		}
+		if cluster.Score < 0.85 {
+			t.Errorf("cluster %+v scores %.3f, below the threshold", cluster.Records, cluster.Score)
+		}
+	}
+}
+
+func TestClusterPeople(t *testing.T) {
+	records := []PersonRecord{
+		{ID: "a", Name: "Bill Smith"},
+		{ID: "b", Name: "William J. Smith", Email: "bill@example.com"},
+		{ID: "c", Name: "Smith, W."},
+		{ID: "d", Name: "Mary Jones"},
+		{ID: "e", Name: "Bill Smith"},
+	}
+
+	clusters := ClusterPeople(records, ClusterOptions{})
+	if len(clusters) != 1 {
+		t.Fatalf("ClusterPeople gave %d clusters, want 1: %+v", len(clusters), clusters)
+	}
+	if got := len(clusters[0].Records); got != 4 {
+		t.Errorf("cluster has %d records, want 4: %+v", got, clusters[0].Records)
+	}
+	if clusters[0].Canonical.ID != "b" {
+		t.Errorf("Canonical = %+v, want record b", clusters[0].Canonical)
+	}
+}
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+)
+
+// NameEntity classifies what a name refers to
+type NameEntity string
+
+// Name entities returned by ParseNameEntity
+const (
+	EntityUnknown      NameEntity = "unknown"
+	EntityPerson       NameEntity = "person"
+	EntityCouple       NameEntity = "couple"       // "John and Jane Doe"
+	EntityOrganization NameEntity = "organization" // "Acme Widgets, Inc."
+	EntityTrustEstate  NameEntity = "trust_estate" // "Estate of John Doe"
+)
+
+var (
+	trustEstate        = []string{"TRUST", "TRUSTS", "TRUSTEE", "TRUSTEES", "TTEE", "TTEES", "ESTATE"}
+	coupleConjunctions = []string{"AND", "&", "+"}
+
+	// firmWords follow a conjunction in a firm's name: "Smith and Sons",
+	// "Jones & Co."
+	firmWords = []string{"SON", "SONS", "DAUGHTERS", "BROTHERS", "BROS", "CO", "COMPANY", "ASSOCIATES", "PARTNERS"}
+
+	// ambiguousEntities are legal-entity suffixes that are also name words
+	// (Kim Na, Jose Sa); they only count when punctuated: "N.A.", "Acme, Sa"
+	ambiguousEntities = []string{"NA", "SA", "AG", "PC", "LP", "BV", "NV", "AB", "AS", "OY", "SE", "KK"}
+)
+
+// ParsedName is a name's classification along with the people it names:
+// one for a person, two for a couple, and none for anything else
+type ParsedName struct {
+	ProvidedName string      `json:"provided_name"`
+	Entity       NameEntity  `json:"entity"`
+	People       []NameParts `json:"people"`
+}
+
+/*
+ParseNameEntity classifies a name as a person, couple, organization or
+trust/estate and parses the people in it. Joint names expand into two
+people sharing the surname: "Mr. and Mrs. John Smith" gives Mr. John Smith
+and Mrs. Smith, "John and Jane Doe" gives John Doe and Jane Doe, and
+"Smith, John and Jane" gives John Smith and Jane Smith.
+*/
+func ParseNameEntity(name string) ParsedName {
+	return defaultNameParser.ParseEntity(name)
+}
+
+// ParseEntity is ParseNameEntity using the parser's vocabularies
+func (np *NameParser) ParseEntity(name string) ParsedName {
+	result := ParsedName{ProvidedName: name, Entity: EntityUnknown}
+
+	words := strings.Fields(name)
+	switch {
+	case len(words) == 0:
+		return result
+	case np.isOrganization(words):
+		result.Entity = EntityOrganization
+		return result
+	case np.isTrustEstate(words):
+		result.Entity = EntityTrustEstate
+		return result
+	case np.hasFirmSuffix(words):
+		result.Entity = EntityOrganization
+		return result
+	}
+
+	if people, isCouple := np.parseCouple(words); isCouple {
+		if people == nil {
+			// "Smith & Wesson", "Barnes and Noble"
+			if np.isFirmName(words) {
+				result.Entity = EntityOrganization
+			}
+			return result
+		}
+		// The halves may mix the input's case ("JOHN" + "Doe")
+		if isSingleCase(name) {
+			for i := range people {
+				people[i].nameCaseParts()
+				people[i].buildFullName(np.FamilyNameFirst)
+			}
+		}
+		result.Entity = EntityCouple
+		result.People = people
+		return result
+	}
+
+	if p := np.parsePerson(name); p.FirstName != "" || p.LastName != "" {
+		result.Entity = EntityPerson
+		result.People = []NameParts{p}
+	}
+	return result
+}
+
+// isOrganization reports whether any word is an organization word (Bank,
+// University) or a word after the first is a legal-entity suffix (Inc,
+// L.L.C., GmbH)
+func (np *NameParser) isOrganization(words []string) bool {
+	for _, word := range words {
+		if slices.Contains(np.OrganizationWords, entityToken(word)) {
+			return true
+		}
+	}
+
+	for i := 1; i < len(words); i++ {
+		bare := entityToken(words[i])
+		if !slices.Contains(np.CorporateEntities, bare) {
+			continue
+		}
+		if !slices.Contains(ambiguousEntities, bare) ||
+			strings.Contains(words[i], ".") || strings.HasSuffix(words[i-1], ",") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFirmSuffix reports whether a joint name continues with a firm word or
+// legal-entity suffix rather than a second person: "John Smith and Sons",
+// "Jones + Co."
+func (np *NameParser) hasFirmSuffix(words []string) bool {
+	conjunction := coupleConjunction(words)
+	if conjunction < 0 {
+		return false
+	}
+
+	next := entityToken(words[conjunction+1])
+	return slices.Contains(firmWords, next) ||
+		(slices.Contains(np.CorporateEntities, next) && !slices.Contains(ambiguousEntities, next))
+}
+
+// isFirmName reports whether the words are joined by a conjunction and
+// none of them is a salutation or known given name, so "Smith & Wesson"
+// and "Barnes and Noble" are firms while "John & Jane" is left as an
+// unparsed couple
+func (np *NameParser) isFirmName(words []string) bool {
+	conjunction := coupleConjunction(words)
+	if conjunction < 0 {
+		return false
+	}
+	for i, word := range words {
+		if i == conjunction {
+			continue
+		}
+		if slices.Contains(np.Salutations, entityToken(word)) || isKnownGivenName(word) {
+			return false
+		}
+	}
+	return true
+}
+
+// coupleConjunction returns the index of the first conjunction between
+// two words, or -1
+func coupleConjunction(words []string) int {
+	for i := 1; i < len(words)-1; i++ {
+		if slices.Contains(coupleConjunctions, strings.ToUpper(words[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (np *NameParser) isTrustEstate(words []string) bool {
+	for _, word := range words {
+		if slices.Contains(np.TrustEstate, entityToken(word)) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+parseCouple splits a joint name at its conjunction. isCouple reports
+whether there was a conjunction at all; people is nil when there was, but
+the sides don't read as two people ("Smith & Wesson").
+*/
+func (np *NameParser) parseCouple(words []string) (people []NameParts, isCouple bool) {
+	conjunction := coupleConjunction(words)
+	if conjunction < 0 {
+		return nil, false
+	}
+
+	left, right := words[:conjunction], words[conjunction+1:]
+
+	// "Smith, John and Jane"
+	leftText := strings.Join(left, " ")
+	if surname, given, ok := strings.Cut(leftText, ","); ok && !strings.Contains(strings.Join(right, " "), ",") {
+		givenWords := strings.Fields(given)
+		if len(givenWords) > 0 && !np.isGenerationOrSuffix(givenWords[0]) {
+			surname = strings.TrimSpace(surname)
+			return []NameParts{
+				np.parsePerson(strings.TrimSpace(given) + " " + surname),
+				np.parsePerson(strings.Join(right, " ") + " " + surname),
+			}, true
+		}
+	}
+
+	leftSalutation, leftNames := np.splitSalutation(left)
+	rightSalutation, rightNames := np.splitSalutation(right)
+
+	switch {
+	// "John Smith and Jane Doe"
+	case len(leftNames) > 1 && len(rightNames) > 1:
+		return []NameParts{np.parsePerson(leftText), np.parsePerson(strings.Join(right, " "))}, true
+
+	// "John and Jane Doe", "Mr. John and Mrs. Jane Doe"
+	case len(leftNames) == 1 && len(rightNames) > 1:
+		second := np.parsePerson(strings.Join(right, " "))
+		first := np.parsePerson(leftText + " " + second.LastName)
+		return []NameParts{first, second}, true
+
+	// "John Doe and Jane"
+	case len(leftNames) > 1 && len(rightNames) == 1:
+		first := np.parsePerson(leftText)
+		second := np.parsePerson(strings.Join(right, " ") + " " + first.LastName)
+		return []NameParts{first, second}, true
+
+	// "Mr. and Mrs. John Smith": the second salutation takes the surname
+	case len(leftNames) == 0 && leftSalutation != "" && len(rightNames) > 1:
+		first := np.parsePerson(leftSalutation + " " + strings.Join(rightNames, " "))
+		return []NameParts{first, np.surnameOnly(rightSalutation, first)}, true
+
+	// "Mr. and Mrs. Smith"
+	case len(leftNames) == 0 && leftSalutation != "" && len(rightNames) == 1:
+		surname := NameParts{LastName: rightNames[0]}
+		return []NameParts{np.surnameOnly(leftSalutation, surname), np.surnameOnly(rightSalutation, surname)}, true
+	}
+
+	return nil, true
+}
+
+// splitSalutation separates a leading salutation from the remaining words
+func (np *NameParser) splitSalutation(words []string) (string, []string) {
+	if len(words) > 0 && slices.Contains(np.Salutations, entityToken(words[0])) {
+		return words[0], words[1:]
+	}
+	return "", words
+}
+
+func (np *NameParser) isGenerationOrSuffix(word string) bool {
+	bare := entityToken(word)
+	return slices.Contains(np.Generations, bare) || slices.Contains(np.Suffixes, bare)
+}
+
+// surnameOnly returns a person known only by salutation and from's surname
+func (np *NameParser) surnameOnly(salutation string, from NameParts) NameParts {
+	p := NameParts{
+		Salutation:       salutation,
+		LastName:         from.LastName,
+		PaternalLastName: from.PaternalLastName,
+		MaternalLastName: from.MaternalLastName,
+		Entity:           EntityPerson,
+	}
+	if isSingleCase(salutation + from.LastName) {
+		p.nameCaseParts()
+	}
+	p.buildFullName(np.FamilyNameFirst)
+	p.ProvidedName = p.FullName
+	return p
+}
+
+// entityToken upper-cases a word and strips its periods and commas
+func entityToken(word string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "", ",", "").Replace(word))
+}
@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestParseNameEntity(t *testing.T) {
+	tests := []struct {
+		in     string
+		entity NameEntity
+		people int
+	}{
+		{"John Smith", EntityPerson, 1},
+		{"John and Jane Doe", EntityCouple, 2},
+		{"Mr. and Mrs. John Smith", EntityCouple, 2},
+		{"Acme Widgets, Inc.", EntityOrganization, 0},
+		{"Kim Na", EntityPerson, 1},
+		{"Wells Fargo Bank", EntityOrganization, 0},
+		{"Bank of America", EntityOrganization, 0},
+		{"Acme Associates", EntityOrganization, 0},
+		{"Ohio State University", EntityOrganization, 0},
+		{"Gates Foundation", EntityOrganization, 0},
+		{"First Baptist Church", EntityOrganization, 0},
+		{"Smith & Wesson", EntityOrganization, 0},
+		{"Procter & Gamble", EntityOrganization, 0},
+		{"John & Jane", EntityUnknown, 0},
+		{"Estate of John Doe", EntityTrustEstate, 0},
+		// the baseline's corporate tokens
+		{"Wells Fargo", EntityOrganization, 0},
+		{"State of Ohio", EntityOrganization, 0},
+		{"Acme Co", EntityOrganization, 0},
+		// firm words after a conjunction aren't a second person
+		{"John Smith and Sons", EntityOrganization, 0},
+		{"John Smith + Co", EntityOrganization, 0},
+		{"Jones & Co.", EntityOrganization, 0},
+		{"Smith Brothers and Company", EntityOrganization, 0},
+		{"Barnes and Noble", EntityOrganization, 0},
+		{"Bob Smith and Jane Doe", EntityCouple, 2},
+	}
+	for _, tt := range tests {
+		got := ParseNameEntity(tt.in)
+		if got.Entity != tt.entity || len(got.People) != tt.people {
+			t.Errorf("ParseNameEntity(%q) = %s with %d people, want %s with %d", tt.in, got.Entity, len(got.People), tt.entity, tt.people)
+		}
+	}
+
+	if p := ParseName("Wells Fargo Bank"); p.FirstName != "" || p.LastName != "" || p.Entity != EntityOrganization {
+		t.Errorf(`ParseName("Wells Fargo Bank") = %+v, want an organization`, p)
+	}
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Preset patterns for NameParts.Format
+const (
+	// NameFormatFormal addresses someone formally, e.g. "Dr. Smith"
+	NameFormatFormal = "[{Salutation} ]{Last}"
+	// NameFormatInformal greets someone by nickname or first name, e.g. "Bob"
+	NameFormatInformal = "{Informal}"
+	// NameFormatLastFirst is the directory style, e.g. "Smith, John Q."
+	NameFormatLastFirst = "{Last}[, {First}][ {MiddleInitial}.][, {Generation}]"
+	// NameFormatCitation is the bibliography style, e.g. "Smith, J. Q."
+	NameFormatCitation = "{Last}[, {FirstInitial}.][ {MiddleInitial}.]"
+	// NameFormatInitials abbreviates the given names, e.g. "J. Q. Smith"
+	NameFormatInitials = "[{FirstInitial}. ][{MiddleInitial}. ]{Last}"
+	// NameFormatSortKey orders by surname, e.g. "smith john quincy jr";
+	// use NameParts.SortKey, which also folds case and accents
+	NameFormatSortKey = "{Last}[ {First}][ {Middle}][ {Generation}]"
+)
+
+var (
+	reFormatSpaces      = regexp.MustCompile(`\s{2,}`)
+	reFormatSpacePunct  = regexp.MustCompile(`\s+([,.])`)
+	reFormatDoubleComma = regexp.MustCompile(`,{2,}`)
+	reFormatDoubleDot   = regexp.MustCompile(`\.{2,}`)
+)
+
+/*
+Format renders the name using a small pattern language:
+
+	{Part}     inserts a part of the name
+	[ ... ]    an optional section, dropped unless every part inside it is present
+
+Parts are Salutation, First, Middle, Last, Generation, Suffix, Nickname,
+Full, Informal (nickname, else first name), FirstInitial, MiddleInitial,
+LastInitial and Initials (e.g. "JQS"). Names are case-insensitive, and
+unknown parts are rendered empty. Literal text outside a section is always
+kept, but the result is tidied so a missing part doesn't leave doubled
+spaces, a space before a comma or period, or a dangling comma; put
+separators inside a section to drop them with their part:
+
+	p.Format("{Last}[, {First}][ {MiddleInitial}.]") // Smith, John Q.
+*/
+func (p NameParts) Format(pattern string) string {
+	out, _ := p.renderPattern(pattern)
+
+	out = reFormatSpaces.ReplaceAllString(out, " ")
+	out = reFormatSpacePunct.ReplaceAllString(out, "$1")
+	// Only repeats of one mark: "Q., III" keeps both
+	out = reFormatDoubleComma.ReplaceAllString(out, ",")
+	out = reFormatDoubleDot.ReplaceAllString(out, ".")
+	out = strings.Trim(out, " ,")
+
+	return out
+}
+
+// SortKey returns a lower-case, accent-folded key for ordering by surname
+func (p NameParts) SortKey() string {
+	key := strings.NewReplacer(".", "", ",", "").Replace(p.Format(NameFormatSortKey))
+	return strings.ToLower(ReplaceAccents(key))
+}
+
+// renderPattern renders pattern and reports whether every part it
+// referenced (outside nested sections) was present
+func (p NameParts) renderPattern(pattern string) (string, bool) {
+	var out strings.Builder
+	complete := true
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				out.WriteString(pattern[i:])
+				return out.String(), complete
+			}
+			value := p.formatPart(pattern[i+1 : i+end])
+			if value == "" {
+				complete = false
+			}
+			out.WriteString(value)
+			i += end
+		case '[':
+			end := matchingBracket(pattern, i)
+			if end < 0 {
+				out.WriteString(pattern[i:])
+				return out.String(), complete
+			}
+			if section, ok := p.renderPattern(pattern[i+1 : end]); ok {
+				out.WriteString(section)
+			}
+			i = end
+		default:
+			out.WriteByte(pattern[i])
+		}
+	}
+
+	return out.String(), complete
+}
+
+// formatPart returns the value of a named part
+func (p NameParts) formatPart(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "salutation":
+		return p.Salutation
+	case "first":
+		return p.FirstName
+	case "middle":
+		return p.MiddleName
+	case "last":
+		return p.LastName
+	case "generation":
+		return p.Generation
+	case "suffix":
+		return p.Suffix
+	case "nickname":
+		return strings.Trim(p.Nickname, `'"`)
+	case "full":
+		return p.FullName
+	case "informal":
+		if nick := strings.Trim(p.Nickname, `'"`); nick != "" {
+			return nick
+		}
+		return p.FirstName
+	case "firstinitial":
+		return initial(p.FirstName)
+	case "middleinitial":
+		return initial(p.MiddleName)
+	case "lastinitial":
+		return initial(p.LastName)
+	case "initials":
+		var initials strings.Builder
+		for _, part := range []string{p.FirstName, p.MiddleName, p.LastName} {
+			for _, word := range strings.Fields(part) {
+				initials.WriteString(initial(word))
+			}
+		}
+		return initials.String()
+	}
+	return ""
+}
+
+// initial returns the upper-cased first letter of s
+func initial(s string) string {
+	s = strings.TrimLeftFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return ""
+	}
+	return string(unicode.ToUpper(r))
+}
+
+// matchingBracket returns the index of the ] closing the [ at open,
+// or -1 if there is none
+func matchingBracket(pattern string, open int) int {
+	depth := 0
+	for i := open; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
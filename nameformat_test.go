@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestNameFormat(t *testing.T) {
+	tests := []struct {
+		name, pattern, want string
+	}{
+		{"John Q. Smith III", NameFormatLastFirst, "Smith, John Q., III"},
+		{"John Q. Smith Jr.", NameFormatLastFirst, "Smith, John Q., Jr."},
+		{"John Q. Smith", NameFormatLastFirst, "Smith, John Q."},
+		{"John Smith", NameFormatLastFirst, "Smith, John"},
+		{"John Smith Jr.", "{First} {Last}, {Generation}.", "John Smith, Jr."},
+		{"John Q. Smith", NameFormatCitation, "Smith, J. Q."},
+		{"Dr. Jane Doe", NameFormatFormal, "Dr. Doe"},
+		{"Smith", "{Last}, {First}", "Smith"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseName(tt.name).Format(tt.pattern); got != tt.want {
+			t.Errorf("ParseName(%q).Format(%q) = %q, want %q", tt.name, tt.pattern, got, tt.want)
+		}
+	}
+}
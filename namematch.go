@@ -0,0 +1,210 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// NameMatch is the result of MatchNames
+type NameMatch struct {
+	Score   float64  `json:"score"`   // 0 (different people) through 1 (same name)
+	Reasons []string `json:"reasons"` // how each part compared
+}
+
+// String returns the score and reasons, e.g.
+// "0.93: last names match; first names are nickname equivalents"
+func (m NameMatch) String() string {
+	return fmt.Sprintf("%.2f: %s", m.Score, strings.Join(m.Reasons, "; "))
+}
+
+// matchName is a NameParts folded for comparison
+type matchName struct {
+	first      string
+	middle     string
+	last       string
+	generation string
+}
+
+// MatchNames scores how likely it is that a and b name the same person,
+// e.g. "Bill Smith", "William J. Smith", "Smith, W." and "Dr. William
+// Smith Jr.". Names are compared without case or accents (see
+// ReplaceAccents), and the comparison allows for nicknames, initials in
+// place of full names, missing middle names, and first and last names
+// entered in swapped order. Last names that are spelled differently but
+// sound alike (Smith and Smyth, by DoubleMetaphone) count as a partial
+// match. Salutations and suffixes are ignored; differing generations
+// (Jr. vs. Sr.) count heavily against a match.
+func MatchNames(a, b NameParts) NameMatch {
+	x := foldForMatch(a)
+	y := foldForMatch(b)
+
+	best := compareNames(x, y)
+
+	// Try b with its first and last names swapped, at a small discount
+	swapped := y
+	swapped.first, swapped.last = y.last, y.first
+	if m := compareNames(x, swapped); m.Score*0.95 > best.Score {
+		m.Score *= 0.95
+		m.Reasons = append(m.Reasons, "first and last names appear swapped")
+		best = m
+	}
+
+	return best
+}
+
+// compareNames weighs the last name at 0.5, the first at 0.35, and the
+// middle at 0.15
+func compareNames(x, y matchName) NameMatch {
+	var m NameMatch
+
+	last, reason := compareLastNames(x.last, y.last)
+	m.Reasons = append(m.Reasons, reason)
+
+	first, reason := compareGivenNames(x.first, y.first, "first")
+	m.Reasons = append(m.Reasons, reason)
+
+	middle, reason := compareGivenNames(x.middle, y.middle, "middle")
+	m.Reasons = append(m.Reasons, reason)
+
+	m.Score = 0.5*last + 0.35*first + 0.15*middle
+
+	switch {
+	case x.generation != "" && y.generation != "" && x.generation != y.generation:
+		m.Score *= 0.4
+		m.Reasons = append(m.Reasons, fmt.Sprintf("generations differ (%s vs. %s)", x.generation, y.generation))
+	case x.generation != "" && x.generation == y.generation:
+		m.Reasons = append(m.Reasons, "generations match")
+	}
+
+	// A different surname rules out a match regardless of given names
+	if last == 0 {
+		m.Score *= 0.3
+	}
+
+	return m
+}
+
+func compareLastNames(x, y string) (float64, string) {
+	switch {
+	case x == "" || y == "":
+		return 0.5, "a last name is missing"
+	case x == y:
+		return 1, "last names match"
+	}
+
+	// Compound surnames: "garcia lopez" vs. "garcia"
+	xParts, yParts := strings.Fields(x), strings.Fields(y)
+	for _, xp := range xParts {
+		for _, yp := range yParts {
+			if xp == yp && len(xp) > 1 {
+				return 0.8, "last names share a part"
+			}
+		}
+	}
+
+	// Spelling variants: "smyth" vs. "smith"
+	if xPrimary, xAlternate := DoubleMetaphone(x); xPrimary != "" {
+		yPrimary, yAlternate := DoubleMetaphone(y)
+		if xPrimary == yPrimary || xPrimary == yAlternate || xAlternate == yPrimary {
+			return 0.75, "last names sound alike"
+		}
+	}
+
+	return 0, "last names differ"
+}
+
+func compareGivenNames(x, y string, which string) (float64, string) {
+	switch {
+	case x == "" || y == "":
+		return 0.7, fmt.Sprintf("a %s name is missing", which)
+	case x == y:
+		return 1, fmt.Sprintf("%s names match", which)
+	case len([]rune(x)) == 1 || len([]rune(y)) == 1:
+		if []rune(x)[0] == []rune(y)[0] {
+			return 0.8, fmt.Sprintf("%s initial matches", which)
+		}
+		// "W." vs. "Bill"
+		if initialMatchesNickname(x, y) || initialMatchesNickname(y, x) {
+			return 0.7, fmt.Sprintf("%s initial matches a nickname equivalent", which)
+		}
+		return 0, fmt.Sprintf("%s initials differ", which)
+	case givenNamesEquivalent(x, y):
+		return 0.9, fmt.Sprintf("%s names are nickname equivalents", which)
+	}
+
+	// Names of different lengths that start alike: "mary ann" vs. "mary",
+	// or an initial: "j q" vs. "james"
+	xFields, yFields := strings.Fields(x), strings.Fields(y)
+	if len(xFields) != len(yFields) && wordsStartAlike(xFields[0], yFields[0]) {
+		return 0.8, fmt.Sprintf("%s names overlap", which)
+	}
+
+	return 0, fmt.Sprintf("%s names differ", which)
+}
+
+// wordsStartAlike reports whether x and y are the same word, or one is
+// the other's initial
+func wordsStartAlike(x, y string) bool {
+	if x == y {
+		return true
+	}
+	xRunes, yRunes := []rune(x), []rune(y)
+	return (len(xRunes) == 1 || len(yRunes) == 1) && xRunes[0] == yRunes[0]
+}
+
+// initialMatchesNickname reports whether the one-letter *initial* starts
+// a name equivalent to *name*
+func initialMatchesNickname(initial, name string) bool {
+	if len([]rune(initial)) != 1 {
+		return false
+	}
+	for _, equivalent := range GivenNameEquivalents(name) {
+		if strings.HasPrefix(equivalent, initial) {
+			return true
+		}
+	}
+	return false
+}
+
+// foldForMatch lower-cases, strips accents and punctuation, and
+// normalizes generations (Jr. => jr, 2nd => ii)
+func foldForMatch(p NameParts) matchName {
+	return matchName{
+		first:      foldNamePart(p.FirstName),
+		middle:     foldNamePart(p.MiddleName),
+		last:       foldNamePart(p.LastName),
+		generation: foldGeneration(p.Generation),
+	}
+}
+
+func foldNamePart(s string) string {
+	s = strings.ToLower(ReplaceAccents(s))
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r):
+			return r
+		case r == '-' || unicode.IsSpace(r):
+			return ' '
+		}
+		return -1
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func foldGeneration(s string) string {
+	s = strings.ToLower(strings.Trim(strings.TrimSpace(s), ".,"))
+	switch s {
+	case "junior":
+		return "jr"
+	case "senior":
+		return "sr"
+	case "2nd", "second":
+		return "ii"
+	case "3rd", "third":
+		return "iii"
+	case "4th", "fourth":
+		return "iv"
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestMatchNames(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"William Smith", "William Smith", 0.95, 1},
+		{"Bill Smith", "William J. Smith", 0.85, 1},
+		{"John Smith", "J. Smith", 0.85, 0.9},
+		{"John Smith", "Jane Smith", 0.55, 0.65},
+		{"Smith, John", "John Smith", 0.95, 1},
+		{"John Smith Jr.", "John Smith Sr.", 0, 0.45},
+		// spelling variants of the last name are a partial match
+		{"John Smith", "John Smyth", 0.8, 0.9},
+		{"John Smith", "John Jones", 0, 0.3},
+	}
+	for _, tt := range tests {
+		m := MatchNames(ParseName(tt.a), ParseName(tt.b))
+		if m.Score < tt.min || m.Score > tt.max {
+			t.Errorf("MatchNames(%q, %q) = %s, want a score from %.2f to %.2f", tt.a, tt.b, m, tt.min, tt.max)
+		}
+	}
+}
+
+func TestCompareGivenNamesOverlap(t *testing.T) {
+	tests := []struct {
+		x, y  string
+		score float64
+	}{
+		{"j q", "james", 0.8},
+		{"james", "j q", 0.8},
+		{"mary ann", "mary", 0.8},
+		{"j q", "robert", 0},
+		{"mary ann", "marian", 0},
+	}
+	for _, tt := range tests {
+		if got, reason := compareGivenNames(tt.x, tt.y, "middle"); got != tt.score {
+			t.Errorf("compareGivenNames(%q, %q) = %.2f (%s), want %.2f", tt.x, tt.y, got, reason, tt.score)
+		}
+	}
+}
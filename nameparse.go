@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"slices"
 	"sort"
 	"strings"
 )
@@ -18,24 +19,34 @@ var (
 	suffixes         = []string{"ESQ", "PHD", "MD"}
 	lnPrefixes       = []string{"DE", "DA", "DI", "LA", "DU", "DEL", "DEI", "VDA", "DELLO", "DELLA", "DEGLI", "DELLE", "VAN", "VON", "DER", "DEN", "HEER", "TEN", "TER", "VANDE", "VANDEN", "VANDER", "VOOR", "VER", "AAN", "MC", "BEN", "SAN", "SAINZ", "BIN", "LI", "LE", "DES", "AM", "AUS'M", "VOM", "ZUM", "ZUR", "TEN", "IBN"}
 	nonName          = []string{"A.K.A", "AKA", "A/K/A", "F.K.A", "FKA", "F/K/A", "N/K/A"}
-	corpEntity       = []string{"NA", "CORP", "CO", "INC", "ASSOCIATES", "SERVICE", "LLC", "LLP", "PARTNERS", "R/A", "C/O", "COUNTY", "STATE", "BANK", "GROUP", "MUTUAL", "FARGO"}
+	corpEntity       = []string{"INC", "INCORPORATED", "CORP", "CORPORATION", "CO", "COMPANY", "LLC", "LLP", "LP", "LTD", "LIMITED", "PLC", "PLLC", "PC", "NA", "GMBH", "AG", "SA", "SAS", "SARL", "SPA", "BV", "NV", "PTY", "KK", "AB", "AS", "OY", "SE"}
+	orgWords         = []string{"ASSOCIATES", "ASSOCIATION", "SERVICE", "SERVICES", "PARTNERS", "GROUP", "BANK", "MUTUAL", "COUNTY", "UNIVERSITY", "COLLEGE", "SCHOOL", "ACADEMY", "INSTITUTE", "FOUNDATION", "CHURCH", "HOSPITAL", "CLINIC", "SOCIETY", "CLUB", "DEPARTMENT", "AGENCY", "FUND", "STATE", "FARGO", "R/A", "C/O"}
 	supplementalInfo = []string{"WIFE OF", "HUSBAND OF", "SON OF", "DAUGHTER OF", "DECEASED", "FICTITIOUS"}
 )
 
 /*
-NameParts represents the slotted components of a given name
+NameParts represents the slotted components of a given name.
+FormalFirstName is the formal form of a nickname used as the first name
+("Bob" => "Robert"; see FormalFirstName), or blank. PaternalLastName and
+MaternalLastName split a double surname ("García López") and are only
+filled by a NameParser with DoubleSurname set. Entity classifies the name
+(see ParseNameEntity).
 */
 type NameParts struct {
-	ProvidedName string      `json:"provided_name"`
-	FullName     string      `json:"full_name"`
-	Salutation   string      `json:"salutation"`
-	FirstName    string      `json:"first_name"`
-	MiddleName   string      `json:"middle_name"`
-	LastName     string      `json:"last_name"`
-	Generation   string      `json:"generation"`
-	Suffix       string      `json:"suffix"`
-	Nickname     string      `json:"nickname"`
-	Aliases      []NameParts `json:"aliases"`
+	ProvidedName     string      `json:"provided_name"`
+	FullName         string      `json:"full_name"`
+	Salutation       string      `json:"salutation"`
+	FirstName        string      `json:"first_name"`
+	FormalFirstName  string      `json:"formal_first_name"`
+	MiddleName       string      `json:"middle_name"`
+	LastName         string      `json:"last_name"`
+	PaternalLastName string      `json:"paternal_last_name"`
+	MaternalLastName string      `json:"maternal_last_name"`
+	Generation       string      `json:"generation"`
+	Suffix           string      `json:"suffix"`
+	Nickname         string      `json:"nickname"`
+	Aliases          []NameParts `json:"aliases"`
+	Entity           NameEntity  `json:"entity"`
 }
 
 func (p *NameParts) slot(part string, value string) {
@@ -58,13 +69,17 @@ func (p *NameParts) slot(part string, value string) {
 
 }
 
-func (p *NameParts) buildFullName() {
+func (p *NameParts) buildFullName(familyFirst bool) {
 	var fullNameParts []string
 
 	if len(p.Salutation) > 0 {
 		fullNameParts = append(fullNameParts, p.Salutation)
 	}
 
+	if familyFirst && len(p.LastName) > 0 {
+		fullNameParts = append(fullNameParts, p.LastName)
+	}
+
 	if len(p.FirstName) > 0 {
 		fullNameParts = append(fullNameParts, p.FirstName)
 	}
@@ -73,7 +88,7 @@ func (p *NameParts) buildFullName() {
 		fullNameParts = append(fullNameParts, p.MiddleName)
 	}
 
-	if len(p.LastName) > 0 {
+	if !familyFirst && len(p.LastName) > 0 {
 		fullNameParts = append(fullNameParts, p.LastName)
 	}
 
@@ -90,22 +105,45 @@ func (p *NameParts) buildFullName() {
 }
 
 /*
-ParseName takes a string name as a parameter and returns a populated NameParts object
+ParseName takes a string name as a parameter and returns a populated NameParts object.
+It uses the default vocabularies and Western given-family order; see NameParser
+for other configurations.
 */
 func ParseName(name string) NameParts {
+	return defaultNameParser.Parse(name)
+}
+
+/*
+Parse takes a string name as a parameter and returns a NameParts object populated
+using the parser's vocabularies and name order. Entity tells what the name refers
+to; organizations and trusts come back with only ProvidedName set, and a couple
+returns its first person (see ParseEntity for both).
+*/
+func (np *NameParser) Parse(name string) NameParts {
 	if strings.TrimSpace(name) == "" {
 		return NameParts{}
 	}
-	n := nameString{FullName: name}
-	n.normalize()
 
-	p := NameParts{ProvidedName: name, Nickname: n.Nickname}
+	parsed := np.ParseEntity(name)
+	if len(parsed.People) == 0 {
+		return NameParts{ProvidedName: name, Entity: parsed.Entity}
+	}
+
+	p := parsed.People[0]
+	p.ProvidedName = name
+	p.Entity = parsed.Entity
+	return p
+}
 
-	// If we're dealing with a business name, just return it back
-	if n.looksCorporate() {
-		return p
+// parsePerson slots the parts of a single person's name
+func (np *NameParser) parsePerson(name string) NameParts {
+	n := nameString{parser: np, FullName: name}
+	if len(n.normalize()) == 0 {
+		return NameParts{ProvidedName: name, Entity: EntityUnknown}
 	}
 
+	p := NameParts{ProvidedName: name, Nickname: n.Nickname, Entity: EntityPerson}
+
 	parts := []string{"generation", "suffix", "lnprefix", "supplemental"}
 	partMap := make(map[string]int)
 	var slotted []int
@@ -138,18 +176,43 @@ func ParseName(name string) NameParts {
 		partMap["nonname"] = -1
 	}
 
-	// Slot FirstName
+	// Slot FirstName, if anything follows the salutation ("Dr." alone has
+	// no first name)
 	partMap["first"] = partMap["salutation"] + 1
-	p.slot("first", n.SplitName[partMap["first"]])
-	slotted = append(slotted, partMap["salutation"]+1)
+
+	// A generation or suffix in the first name's place is a name ("V. Smith",
+	// "I am the Popsicle"), unless nothing follows it ("Dr. Jr.")
+	for _, part := range []string{"generation", "suffix"} {
+		if partMap[part] != partMap["first"] {
+			continue
+		}
+		if partMap["first"] == len(n.SplitName)-1 {
+			partMap["first"] = len(n.SplitName)
+			break
+		}
+		p.slot(part, "")
+		partMap[part] = -1
+		first := partMap["first"]
+		slotted = slices.DeleteFunc(slotted, func(i int) bool { return i == first })
+	}
+
+	if partMap["first"] < len(n.SplitName) {
+		p.slot("first", n.SplitName[partMap["first"]])
+		slotted = append(slotted, partMap["first"])
+	}
+
+	// A prefix can't start the last name if it is the first name ("Van Morrison")
+	if partMap["lnprefix"] > -1 && partMap["lnprefix"] <= partMap["first"] {
+		partMap["lnprefix"] = -1
+	}
 
 	// Slot prefixed LastName
 	if partMap["lnprefix"] > -1 {
 		lnEnd := len(n.SplitName)
-		if partMap["generation"] > -1 {
+		if partMap["generation"] > partMap["lnprefix"] {
 			lnEnd = partMap["generation"]
 		}
-		if partMap["suffix"] > -1 && partMap["generation"] < lnEnd {
+		if partMap["suffix"] > partMap["lnprefix"] && partMap["suffix"] < lnEnd {
 			lnEnd = partMap["suffix"]
 		}
 		// Need to validate the slice parameters make sense
@@ -174,7 +237,7 @@ func ParseName(name string) NameParts {
 		lnPrefix := partMap["lnprefix"]
 		var multiMiddle []string
 		if lnPrefix > -1 {
-			for p := range notSlotted {
+			for _, p := range notSlotted {
 				multiMiddle = append(multiMiddle, n.SplitName[p])
 			}
 			p.slot("middle", strings.Join(multiMiddle, " "))
@@ -201,13 +264,31 @@ func ParseName(name string) NameParts {
 		}
 	}
 
+	// Apply the parser's cultural conventions
+	if np.FamilyNameFirst && !strings.Contains(name, ",") {
+		p.reorderFamilyFirst()
+	}
+	if np.DoubleSurname {
+		p.splitDoubleSurname()
+	}
+
+	// Resolve a nickname used as the first name
+	if formal := FormalFirstName(p.FirstName); formal != p.FirstName {
+		p.FormalFirstName = formal
+	}
+
+	// Capitalize names supplied in all caps or all lower case
+	if isSingleCase(name) {
+		p.nameCaseParts()
+	}
+
 	// Process aliases
 	for _, alias := range n.Aliases {
-		p.Aliases = append(p.Aliases, ParseName(alias))
+		p.Aliases = append(p.Aliases, np.Parse(alias))
 	}
 
 	// Prepare FullName
-	p.buildFullName()
+	p.buildFullName(np.FamilyNameFirst)
 
 	return p
 }
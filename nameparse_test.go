@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// FuzzParseName checks that no input makes the name parsers panic, that
+// every part they return comes from the input's words (or several of
+// them run together, as in "O' Hurley"), and that
+// ParseStrict's error and Diagnostics.Errors agree. It runs the default
+// parser and each culture preset. Inputs that once crashed the parser
+// are kept in testdata/fuzz/FuzzParseName.
+func FuzzParseName(f *testing.F) {
+	for _, seed := range []string{
+		"John Smith",
+		"Smith, John Q., Jr.",
+		"Dr. Jane Doe PhD",
+		"Acme Holdings LLC",
+		"Mr. and Mrs. John Smith",
+		"Robert \"Bob\" O'Brien-Smith",
+		"Juan Carlos García y López",
+		"Wang Xiao Ming",
+		"Jan van der Berg",
+		"Ursula von der Leyen",
+	} {
+		f.Add(seed)
+	}
+
+	parsers := map[string]*NameParser{
+		"default":   NewNameParser(),
+		"hispanic":  NewHispanicNameParser(),
+		"eastasian": NewEastAsianNameParser(),
+		"dutch":     NewDutchNameParser(),
+		"german":    NewGermanNameParser(),
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		words := nameWords(name)
+
+		for preset, np := range parsers {
+			checkNameWords(t, preset+" Parse", name, words, np.Parse(name))
+
+			for _, p := range np.ParseEntity(name).People {
+				checkNameWords(t, preset+" ParseEntity", name, words, p)
+			}
+
+			p, diag, err := np.ParseStrict(name)
+			if (err != nil) != (len(diag.Errors) > 0) {
+				t.Errorf("%s ParseStrict(%q) error = %v, but Diagnostics.Errors = %q", preset, name, err, diag.Errors)
+			}
+			checkNameWords(t, preset+" ParseStrict", name, words, p)
+		}
+
+		ParseName(name)
+		ParseNameEntity(name)
+		ParseNameStrict(name)
+	})
+}
+
+// checkNameWords fails if a part of p holds a word that isn't in the input
+func checkNameWords(t *testing.T, label string, name string, words []string, p NameParts) {
+	t.Helper()
+
+	known := make(map[string]bool, len(words))
+	for _, word := range words {
+		known[word] = true
+	}
+
+	parts := []string{p.Salutation, p.FirstName, p.MiddleName, p.LastName, p.Generation, p.Suffix, p.Nickname}
+	for _, alias := range p.Aliases {
+		parts = append(parts, alias.FirstName, alias.MiddleName, alias.LastName)
+	}
+
+	for _, part := range parts {
+		for _, word := range nameWords(part) {
+			if !joinsWords(word, known) {
+				t.Errorf("%s(%q) returned %q, which isn't one of the input's words", label, name, word)
+			}
+		}
+	}
+}
+
+// joinsWords reports whether word is one or more known words run together
+func joinsWords(word string, known map[string]bool) bool {
+	// reachable[i] reports whether word[:i] is made of known words
+	reachable := make([]bool, len(word)+1)
+	reachable[0] = true
+	for end := 1; end <= len(word); end++ {
+		for start := 0; start < end && !reachable[end]; start++ {
+			reachable[end] = reachable[start] && known[word[start:end]]
+		}
+	}
+	return reachable[len(word)]
+}
+
+// nameWords returns the words of s, case-folded and without punctuation.
+// Folding goes through upper case so letters with several lower-case
+// forms (ϰ and κ) compare equal after NameCase.
+func nameWords(s string) (words []string) {
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		word := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(unicode.ToUpper(r))
+			}
+			return -1
+		}, field)
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
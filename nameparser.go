@@ -0,0 +1,160 @@
+package utils
+
+import "strings"
+
+/*
+NameParser holds the vocabularies and conventions used to split names.
+Each list holds upper-case tokens without periods, as in the package
+defaults. Build one with NewNameParser or a culture preset and adjust
+its fields before use; a NameParser shouldn't be modified while it is
+parsing.
+*/
+type NameParser struct {
+	Salutations       []string // titles before the name: MR, DR, REV
+	Generations       []string // JR, SR, III
+	Suffixes          []string // ESQ, PHD, MD
+	LastNamePrefixes  []string // particles that start a surname: VAN, DE, DELLA
+	NonName           []string // alias separators: AKA, FKA
+	CorporateEntities []string // legal-entity suffixes that mark a business: INC, LLC
+	OrganizationWords []string // words that mark an organization anywhere: BANK, UNIVERSITY
+	TrustEstate       []string // tokens that mark a trust or estate: TRUST, ESTATE
+	SupplementalInfo  []string // trailing text to drop: DECEASED, WIFE OF
+
+	// FamilyNameFirst reads "Wang Xiaoming" as family name, then given
+	// name, and builds FullName in the same order. Names written with a
+	// comma ("Wang, Xiaoming") are already unambiguous.
+	FamilyNameFirst bool
+
+	// DoubleSurname reads the last two name words as paternal and maternal
+	// surnames, so "José García López" has last name "García López"
+	DoubleSurname bool
+}
+
+var defaultNameParser = NewNameParser()
+
+// NewNameParser returns a parser with the default (English) vocabularies
+// and given-family name order, as used by ParseName
+func NewNameParser() *NameParser {
+	return &NameParser{
+		Salutations:       append([]string{}, salutations...),
+		Generations:       append([]string{}, generations...),
+		Suffixes:          append([]string{}, suffixes...),
+		LastNamePrefixes:  append([]string{}, lnPrefixes...),
+		NonName:           append([]string{}, nonName...),
+		CorporateEntities: append([]string{}, corpEntity...),
+		OrganizationWords: append([]string{}, orgWords...),
+		TrustEstate:       append([]string{}, trustEstate...),
+		SupplementalInfo:  append([]string{}, supplementalInfo...),
+	}
+}
+
+// NewHispanicNameParser returns a parser for Spanish and Latin American
+// names with paternal and maternal surnames: "Juan Carlos García y López"
+// has first name Juan, middle name Carlos, and last name "García y
+// López", split into PaternalLastName and MaternalLastName.
+func NewHispanicNameParser() *NameParser {
+	np := NewNameParser()
+	np.DoubleSurname = true
+	np.Salutations = append(np.Salutations, "SR", "SRA", "SRTA", "DON", "DONA", "DOÑA", "LIC", "ING")
+	// SR is a salutation (señor) here, not a generation
+	np.Generations = removeTokens(np.Generations, "SR")
+	np.LastNamePrefixes = []string{"DE", "DEL", "LA", "LAS", "LOS"}
+	return np
+}
+
+// NewEastAsianNameParser returns a parser for Chinese, Japanese and Korean
+// names written family name first: "Wang Xiao Ming" has last name Wang
+// and first name "Xiao Ming".
+func NewEastAsianNameParser() *NameParser {
+	np := NewNameParser()
+	np.FamilyNameFirst = true
+	np.LastNamePrefixes = nil
+	return np
+}
+
+// NewDutchNameParser returns a parser for Dutch and Flemish surname
+// particles: "Jan van der Berg", "Anne ter Horst", "Pieter 't Hooft"
+func NewDutchNameParser() *NameParser {
+	np := NewNameParser()
+	np.LastNamePrefixes = []string{"VAN", "DE", "DEN", "DER", "TER", "TEN", "'T", "HET", "IN", "OP", "UIT", "VER", "VANDER", "VANDEN", "VANDE"}
+	return np
+}
+
+// NewGermanNameParser returns a parser for German surname particles:
+// "Ursula von der Leyen", "Karl-Theodor zu Guttenberg"
+func NewGermanNameParser() *NameParser {
+	np := NewNameParser()
+	np.LastNamePrefixes = []string{"VON", "ZU", "VOM", "ZUM", "ZUR", "AM", "AUF", "AUS", "DER"}
+	return np
+}
+
+// reorderFamilyFirst rearranges a given-first parse so the first name
+// word is the family name and the rest are the given name
+func (p *NameParts) reorderFamilyFirst() {
+	words := strings.Fields(strings.Join([]string{p.FirstName, p.MiddleName, p.LastName}, " "))
+	if len(words) < 2 {
+		return
+	}
+
+	p.LastName = words[0]
+	p.FirstName = strings.Join(words[1:], " ")
+	p.MiddleName = ""
+}
+
+// splitDoubleSurname moves the last middle word into the surname when
+// needed and fills PaternalLastName and MaternalLastName
+func (p *NameParts) splitDoubleSurname() {
+	middle := strings.Fields(p.MiddleName)
+	last := strings.Fields(p.LastName)
+
+	// A single surname word after a middle name: "José García López"
+	// parses with middle García, so take it back, along with a joining
+	// "y" or "e" ("García y López")
+	if len(last) == 1 && len(middle) > 0 {
+		take := 1
+		if n := len(middle); n > 1 && isSurnameConjunction(middle[n-1]) {
+			take = 2
+		}
+		last = append(middle[len(middle)-take:], last...)
+		middle = middle[:len(middle)-take]
+	}
+
+	p.MiddleName = strings.Join(middle, " ")
+	p.LastName = strings.Join(last, " ")
+
+	if len(last) < 2 {
+		return
+	}
+
+	for i, word := range last {
+		if isSurnameConjunction(word) && i > 0 && i < len(last)-1 {
+			p.PaternalLastName = strings.Join(last[:i], " ")
+			p.MaternalLastName = strings.Join(last[i+1:], " ")
+			return
+		}
+	}
+	p.PaternalLastName = strings.Join(last[:len(last)-1], " ")
+	p.MaternalLastName = last[len(last)-1]
+}
+
+func isSurnameConjunction(word string) bool {
+	return strings.EqualFold(word, "y") || strings.EqualFold(word, "e")
+}
+
+// removeTokens returns list without the given tokens
+func removeTokens(list []string, tokens ...string) []string {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		keep := true
+		for _, token := range tokens {
+			if item == token {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			result = append(result, item)
+		}
+	}
+	return result
+}
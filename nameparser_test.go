@@ -0,0 +1,61 @@
+package utils
+
+import "testing"
+
+// presetCase is a name and the parts a preset should split it into
+type presetCase struct {
+	in                              string
+	salutation, first, middle, last string
+	paternal, maternal, fullName    string
+}
+
+func testPreset(t *testing.T, label string, np *NameParser, tests []presetCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		p := np.Parse(tt.in)
+		got := presetCase{tt.in, p.Salutation, p.FirstName, p.MiddleName, p.LastName, p.PaternalLastName, p.MaternalLastName, p.FullName}
+		if got != tt {
+			t.Errorf("%s Parse(%q) =\n\t%+v, want\n\t%+v", label, tt.in, got, tt)
+		}
+	}
+}
+
+func TestHispanicNameParser(t *testing.T) {
+	testPreset(t, "Hispanic", NewHispanicNameParser(), []presetCase{
+		{"Juan Carlos García y López", "", "Juan", "Carlos", "García y López", "García", "López", "Juan Carlos García y López"},
+		{"José García López", "", "José", "", "García López", "García", "López", "José García López"},
+		{"Gabriel José García Márquez", "", "Gabriel", "José", "García Márquez", "García", "Márquez", "Gabriel José García Márquez"},
+		{"Sra. María Fernández", "Sra.", "María", "", "Fernández", "", "", "Sra. María Fernández"},
+		{"Juan Pérez", "", "Juan", "", "Pérez", "", "", "Juan Pérez"},
+		{"María de la Cruz Ruiz", "", "María", "", "de la Cruz Ruiz", "de la Cruz", "Ruiz", "María de la Cruz Ruiz"},
+	})
+}
+
+func TestEastAsianNameParser(t *testing.T) {
+	testPreset(t, "EastAsian", NewEastAsianNameParser(), []presetCase{
+		{"Wang Xiao Ming", "", "Xiao Ming", "", "Wang", "", "", "Wang Xiao Ming"},
+		{"Kim Jong Un", "", "Jong Un", "", "Kim", "", "", "Kim Jong Un"},
+		{"Tanaka Hiroshi", "", "Hiroshi", "", "Tanaka", "", "", "Tanaka Hiroshi"},
+		// the comma already says which is the family name
+		{"Wang, Xiaoming", "", "Xiaoming", "", "Wang", "", "", "Wang Xiaoming"},
+	})
+}
+
+func TestDutchNameParser(t *testing.T) {
+	testPreset(t, "Dutch", NewDutchNameParser(), []presetCase{
+		{"Jan van der Berg", "", "Jan", "", "van der Berg", "", "", "Jan van der Berg"},
+		{"Anne ter Horst", "", "Anne", "", "ter Horst", "", "", "Anne ter Horst"},
+		{"Pieter 't Hooft", "", "Pieter", "", "'t Hooft", "", "", "Pieter 't Hooft"},
+		{"Vincent Willem van Gogh", "", "Vincent", "Willem", "van Gogh", "", "", "Vincent Willem van Gogh"},
+	})
+}
+
+func TestGermanNameParser(t *testing.T) {
+	testPreset(t, "German", NewGermanNameParser(), []presetCase{
+		{"Ursula von der Leyen", "", "Ursula", "", "von der Leyen", "", "", "Ursula von der Leyen"},
+		{"Karl-Theodor zu Guttenberg", "", "Karl-Theodor", "", "zu Guttenberg", "", "", "Karl-Theodor zu Guttenberg"},
+		{"Otto von Bismarck", "", "Otto", "", "von Bismarck", "", "", "Otto von Bismarck"},
+		{"Dr. Angela Dorothea Merkel", "Dr.", "Angela", "Dorothea", "Merkel", "", "", "Dr. Angela Dorothea Merkel"},
+	})
+}
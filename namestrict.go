@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenSlot records where ParseNameStrict placed one word of a name. Slot
+// is salutation, first, middle, last, generation, suffix, nickname or alias.
+type TokenSlot struct {
+	Token string `json:"token"`
+	Slot  string `json:"slot"`
+}
+
+// Diagnostics explains how ParseNameStrict read a name. Errors holds
+// why the name was rejected, and is set exactly when ParseNameStrict
+// returns an error.
+type Diagnostics struct {
+	Entity   NameEntity  `json:"entity"`
+	Tokens   []TokenSlot `json:"tokens"`
+	Warnings []string    `json:"warnings"`
+	Errors   []string    `json:"errors"`
+}
+
+/*
+ParseNameStrict is ParseName for untrusted input. Along with the parsed
+name it returns Diagnostics listing the slot each word went to and
+warnings for words that could be read more than one way ("V" as an
+initial or a generation, "Van" as a first name or a surname particle).
+It returns an error, with whatever parts it found, for blank input,
+invalid UTF-8, organizations and trusts, and names without a first or
+last name ("Dr.").
+*/
+func ParseNameStrict(name string) (NameParts, Diagnostics, error) {
+	return defaultNameParser.ParseStrict(name)
+}
+
+// ParseStrict is ParseNameStrict using the parser's vocabularies
+func (np *NameParser) ParseStrict(name string) (NameParts, Diagnostics, error) {
+	var diag Diagnostics
+
+	switch {
+	case strings.TrimSpace(name) == "":
+		err := diag.fail("name is blank")
+		return NameParts{}, diag, err
+	case !utf8.ValidString(name):
+		err := diag.fail("name is not valid UTF-8")
+		return NameParts{ProvidedName: name}, diag, err
+	}
+
+	parsed := np.ParseEntity(name)
+	diag.Entity = parsed.Entity
+
+	switch parsed.Entity {
+	case EntityOrganization:
+		err := diag.fail(`"%s" names an organization, not a person`, name)
+		return NameParts{ProvidedName: name, Entity: parsed.Entity}, diag, err
+	case EntityTrustEstate:
+		err := diag.fail(`"%s" names a trust or estate, not a person`, name)
+		return NameParts{ProvidedName: name, Entity: parsed.Entity}, diag, err
+	case EntityCouple:
+		diag.warn(`joint name; "%s" is the second person`, parsed.People[1].FullName)
+	}
+
+	var p NameParts
+	if len(parsed.People) > 0 {
+		p = parsed.People[0]
+	} else {
+		p = np.parsePerson(name)
+	}
+	p.ProvidedName = name
+	p.Entity = parsed.Entity
+
+	diag.Tokens = p.tokenSlots()
+	np.diagnose(name, p, &diag)
+
+	var err error
+	switch {
+	case p.FirstName == "" && p.LastName == "":
+		err = diag.fail(`"%s" has no first or last name`, name)
+	case parsed.Entity == EntityUnknown:
+		err = diag.fail(`"%s" couldn't be read as one or two people`, name)
+	}
+	return p, diag, err
+}
+
+// tokenSlots lists the words of each part in reading order
+func (p NameParts) tokenSlots() (result []TokenSlot) {
+	add := func(value, slot string) {
+		for _, word := range strings.Fields(value) {
+			result = append(result, TokenSlot{Token: word, Slot: slot})
+		}
+	}
+
+	add(p.Salutation, "salutation")
+	add(p.FirstName, "first")
+	add(p.Nickname, "nickname")
+	add(p.MiddleName, "middle")
+	add(p.LastName, "last")
+	add(p.Generation, "generation")
+	add(p.Suffix, "suffix")
+	for _, alias := range p.Aliases {
+		add(alias.FullName, "alias")
+	}
+	return result
+}
+
+// diagnose adds warnings for ambiguous and unused words
+func (np *NameParser) diagnose(name string, p NameParts, diag *Diagnostics) {
+	for _, token := range diag.Tokens {
+		bare := entityToken(token.Token)
+
+		switch token.Slot {
+		case "first", "middle", "last":
+			if slices.Contains(np.Salutations, bare) {
+				diag.warn(`"%s" read as a name; it is also a salutation`, token.Token)
+			}
+			if np.isGenerationOrSuffix(bare) {
+				diag.warn(`"%s" read as a name; it is also a generation or suffix`, token.Token)
+			}
+			if token.Slot == "first" && slices.Contains(np.LastNamePrefixes, bare) {
+				diag.warn(`"%s" read as a first name; it is also a surname particle`, token.Token)
+			}
+		case "generation":
+			if utf8.RuneCountInString(bare) == 1 {
+				diag.warn(`"%s" read as a generation; it may be an initial`, token.Token)
+			}
+		}
+	}
+
+	if formal := FormalFirstNames(p.FirstName); len(formal) > 1 {
+		diag.warn(`"%s" has several formal forms: %s`, p.FirstName, strings.Join(formal, ", "))
+	}
+	if p.FirstName != "" && p.LastName == "" {
+		diag.warn(`no last name; "%s" read as a first name`, p.FirstName)
+	}
+
+	// Words the parts don't account for, e.g. supplemental info dropped
+	// by the parser. Couples are skipped since their words are shared out.
+	if p.Entity == EntityCouple {
+		return
+	}
+	used := make(map[string]int)
+	for _, token := range diag.Tokens {
+		used[foldToken(token.Token)]++
+	}
+	for _, word := range strings.Fields(name) {
+		folded := foldToken(word)
+		switch {
+		case folded == "" || slices.Contains(np.NonName, strings.ToUpper(folded)):
+		case used[folded] > 0:
+			used[folded]--
+		default:
+			diag.warn(`"%s" was not used`, word)
+		}
+	}
+}
+
+func (d *Diagnostics) warn(format string, args ...interface{}) {
+	d.Warnings = append(d.Warnings, fmt.Sprintf(format, args...))
+}
+
+// fail records an error in Errors and returns it
+func (d *Diagnostics) fail(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	d.Errors = append(d.Errors, err.Error())
+	return err
+}
+
+// foldToken lower-cases a word and trims surrounding punctuation
+func foldToken(word string) string {
+	return strings.ToLower(strings.Trim(word, `.,'"()`))
+}
@@ -0,0 +1,221 @@
+package utils
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed nicknames.txt
+var defaultNicknames string
+
+// nicknameTable holds groups of equivalent given names. The first
+// name in each group is the formal form.
+type nicknameTable struct {
+	mu     sync.RWMutex
+	groups [][]string
+	index  map[string][]int // folded name => indexes into groups
+}
+
+var nicknames = func() *nicknameTable {
+	t := &nicknameTable{}
+	if err := t.load(strings.NewReader(defaultNicknames)); err != nil {
+		panic(fmt.Sprintf("utils: embedded nicknames.txt: %v", err))
+	}
+	return t
+}()
+
+// LoadNicknames replaces the given-name table with one read from r, in the
+// format of the embedded nicknames.txt:
+//
+//	# comment
+//	robert: bob, bobby, rob
+func LoadNicknames(r io.Reader) error {
+	return nicknames.load(r)
+}
+
+// LoadNicknamesFile replaces the given-name table with the file at *path*
+// (see LoadNicknames)
+func LoadNicknamesFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return LoadNicknames(f)
+}
+
+// AddNicknames adds a group to the given-name table, e.g.
+// AddNicknames("Giuseppe", "Beppe", "Pino")
+func AddNicknames(formal string, names ...string) {
+	group := []string{foldGivenName(formal)}
+	for _, name := range names {
+		group = append(group, foldGivenName(name))
+	}
+
+	nicknames.mu.Lock()
+	defer nicknames.mu.Unlock()
+	nicknames.add(group)
+}
+
+// FormalFirstName returns the formal form of a nickname, matching the
+// input's capitalization: "Bob" => "Robert", "PEGGY" => "MARGARET".
+// Names that aren't known nicknames are returned unchanged. Where a
+// nickname has several formal forms (Kate), the first listed wins; see
+// FormalFirstNames for all of them.
+func FormalFirstName(name string) string {
+	formal := FormalFirstNames(name)
+	if len(formal) == 0 {
+		return name
+	}
+	return matchCase(formal[0], name)
+}
+
+// FormalFirstNames returns every formal form of *name*, lower-cased,
+// or nil if it isn't a known nickname. A name that heads a group of
+// its own (Sandra, Nathan) is already formal and also returns nil.
+func FormalFirstNames(name string) (result []string) {
+	folded := foldGivenName(name)
+
+	nicknames.mu.RLock()
+	defer nicknames.mu.RUnlock()
+
+	for _, i := range nicknames.index[folded] {
+		if nicknames.groups[i][0] == folded {
+			return nil
+		}
+	}
+
+	for _, i := range nicknames.index[folded] {
+		result = append(result, nicknames.groups[i][0])
+	}
+	return result
+}
+
+// GivenNameEquivalents returns *name* and every name equivalent to it in
+// either direction, lower-cased and sorted, for searching:
+// "bob" => [bert bob bobby rob robbie robert]. Unknown names return
+// just themselves.
+func GivenNameEquivalents(name string) []string {
+	folded := foldGivenName(name)
+	if folded == "" {
+		return nil
+	}
+
+	nicknames.mu.RLock()
+	defer nicknames.mu.RUnlock()
+
+	uniques := map[string]bool{folded: true}
+	for _, i := range nicknames.index[folded] {
+		for _, equivalent := range nicknames.groups[i] {
+			uniques[equivalent] = true
+		}
+	}
+
+	result := make([]string, 0, len(uniques))
+	for equivalent := range uniques {
+		result = append(result, equivalent)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// isKnownGivenName reports whether *name* is in the given-name table
+func isKnownGivenName(name string) bool {
+	folded := foldGivenName(name)
+
+	nicknames.mu.RLock()
+	defer nicknames.mu.RUnlock()
+
+	return len(nicknames.index[folded]) > 0
+}
+
+// givenNamesEquivalent reports whether folded names x and y share a group
+func givenNamesEquivalent(x, y string) bool {
+	nicknames.mu.RLock()
+	defer nicknames.mu.RUnlock()
+
+	for _, i := range nicknames.index[x] {
+		for _, j := range nicknames.index[y] {
+			if i == j {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *nicknameTable) load(r io.Reader) error {
+	var groups [][]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		formal, rest, ok := strings.Cut(line, ":")
+		if !ok || foldGivenName(formal) == "" {
+			return fmt.Errorf("nicknames line %d: expected \"formal: nickname, ...\"", lineNo)
+		}
+
+		group := []string{foldGivenName(formal)}
+		for _, name := range strings.Split(rest, ",") {
+			if folded := foldGivenName(name); folded != "" {
+				group = append(group, folded)
+			}
+		}
+		groups = append(groups, group)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.groups = nil
+	t.index = make(map[string][]int)
+	for _, group := range groups {
+		t.add(group)
+	}
+	return nil
+}
+
+// add appends a group; callers hold the write lock
+func (t *nicknameTable) add(group []string) {
+	i := len(t.groups)
+	t.groups = append(t.groups, group)
+	for _, name := range UniqueStrings(group) {
+		t.index[name] = append(t.index[name], i)
+	}
+}
+
+// foldGivenName lower-cases, strips accents, and trims punctuation
+func foldGivenName(name string) string {
+	name = strings.ToLower(ReplaceAccents(strings.TrimSpace(name)))
+	return strings.TrimFunc(name, func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// matchCase returns lower-case *word* in the capitalization style of *model*
+func matchCase(word, model string) string {
+	switch {
+	case model == "":
+		return word
+	case IsUpperCaseWord(model):
+		return strings.ToUpper(word)
+	case unicode.IsUpper([]rune(model)[0]):
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		return string(runes)
+	}
+	return word
+}
@@ -0,0 +1,45 @@
+package utils
+
+import "testing"
+
+func TestNoFormalNameMapsToAnother(t *testing.T) {
+	nicknames.mu.RLock()
+	heads := make(map[string]bool)
+	for _, group := range nicknames.groups {
+		heads[group[0]] = true
+	}
+	nicknames.mu.RUnlock()
+
+	for head := range heads {
+		if formal := FormalFirstNames(head); formal != nil {
+			t.Errorf("FormalFirstNames(%q) = %q, want nil for a formal name", head, formal)
+		}
+	}
+}
+
+func TestFormalFirstName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Bob", "Robert"},
+		{"PEGGY", "MARGARET"},
+		{"Sandy", "Alexander"},
+		{"Sandra", "Sandra"},
+		{"Nathan", "Nathan"},
+		{"Nancy", "Nancy"},
+		{"Nate", "Nathan"},
+		{"Zebulon", "Zebulon"},
+	}
+	for _, tt := range tests {
+		if got := FormalFirstName(tt.in); got != tt.want {
+			t.Errorf("FormalFirstName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for in, want := range map[string]string{"Sandra Smith": "", "Nathan Lee": "", "Nancy Drew": "", "Bob Lee": "Robert"} {
+		if got := ParseName(in).FormalFirstName; got != want {
+			t.Errorf("ParseName(%q).FormalFirstName = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"strings"
+)
+
+// phoneticKeyPrefix marks phonetic keys among Tokenize's literal tokens
+const phoneticKeyPrefix = "~"
+
+// doubleMetaphoneLength is the customary Double Metaphone key length
+const doubleMetaphoneLength = 4
+
+// PhoneticKeys returns the phonetic search keys Tokenize emits for *word*
+// with TokenizeOptions.Phonetic: its Double Metaphone primary and, if
+// different, alternate codes, each prefixed with "~" so they can't collide
+// with literal tokens. "Smith" and "Smyth" both give [~SM0 ~XMT].
+func PhoneticKeys(word string) []string {
+	primary, alternate := DoubleMetaphone(word)
+	if primary == "" {
+		return nil
+	}
+
+	keys := []string{phoneticKeyPrefix + primary}
+	if alternate != primary && alternate != "" {
+		keys = append(keys, phoneticKeyPrefix+alternate)
+	}
+	return keys
+}
+
+// phoneticLetters folds accents, upper-cases, and keeps only A-Z
+func phoneticLetters(s string) string {
+	s = strings.ToUpper(ReplaceAccents(s))
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// Soundex returns the American Soundex code of *s*: its first letter and
+// three digits, e.g. "Robert" and "Rupert" => "R163". Accents are folded
+// and anything other than letters is ignored; s without letters gives "".
+func Soundex(s string) string {
+	letters := phoneticLetters(s)
+	if letters == "" {
+		return ""
+	}
+
+	code := []byte{letters[0]}
+	last := soundexDigit(letters[0])
+	for i := 1; i < len(letters) && len(code) < 4; i++ {
+		c := letters[i]
+		digit := soundexDigit(c)
+		switch {
+		// H and W don't separate letters with the same code
+		case c == 'H' || c == 'W':
+		// Vowels do
+		case digit == 0:
+			last = 0
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+	}
+
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+func soundexDigit(c byte) byte {
+	switch c {
+	case 'B', 'F', 'P', 'V':
+		return '1'
+	case 'C', 'G', 'J', 'K', 'Q', 'S', 'X', 'Z':
+		return '2'
+	case 'D', 'T':
+		return '3'
+	case 'L':
+		return '4'
+	case 'M', 'N':
+		return '5'
+	case 'R':
+		return '6'
+	}
+	return 0
+}
+
+/*
+NYSIIS returns the New York State Identification and Intelligence System
+code of *s*, truncated to the original six characters: "Knight" =>
+"NAGT", "Macintosh" => "MCANT". It is more discriminating than Soundex
+for surnames. Accents are folded and anything other than letters is
+ignored; s without letters gives "".
+*/
+func NYSIIS(s string) string {
+	word := phoneticLetters(s)
+	if word == "" {
+		return ""
+	}
+
+	// Prefixes
+	for _, r := range []struct{ from, to string }{
+		{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"},
+	} {
+		if strings.HasPrefix(word, r.from) {
+			word = r.to + word[len(r.from):]
+			break
+		}
+	}
+
+	// Suffixes
+	for _, r := range []struct{ from, to string }{
+		{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"},
+	} {
+		if strings.HasSuffix(word, r.from) {
+			word = word[:len(word)-len(r.from)] + r.to
+			break
+		}
+	}
+
+	chars := []byte(word)
+	key := []byte{chars[0]}
+	at := func(i int) byte {
+		if i < len(chars) {
+			return chars[i]
+		}
+		return ' '
+	}
+
+	for i := 1; i < len(chars); i++ {
+		prev, curr, next := chars[i-1], chars[i], at(i+1)
+
+		var replacement string
+		switch {
+		case curr == 'E' && next == 'V':
+			replacement = "AF"
+		case isPhoneticVowel(curr):
+			replacement = "A"
+		case curr == 'Q':
+			replacement = "G"
+		case curr == 'Z':
+			replacement = "S"
+		case curr == 'M':
+			replacement = "N"
+		case curr == 'K' && next == 'N':
+			replacement = "NN"
+		case curr == 'K':
+			replacement = "C"
+		case curr == 'S' && next == 'C' && at(i+2) == 'H':
+			replacement = "SSS"
+		case curr == 'P' && next == 'H':
+			replacement = "FF"
+		case curr == 'H' && (!isPhoneticVowel(prev) || !isPhoneticVowel(next)):
+			replacement = string(prev)
+		case curr == 'W' && isPhoneticVowel(prev):
+			replacement = string(prev)
+		default:
+			replacement = string(curr)
+		}
+		copy(chars[i:], replacement)
+
+		// Only add the letter if it differs from the one before
+		if chars[i] != chars[i-1] {
+			key = append(key, chars[i])
+		}
+	}
+
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+	if n := len(key); n > 2 && key[n-2] == 'A' && key[n-1] == 'Y' {
+		key = append(key[:n-2], 'Y')
+	}
+	if len(key) > 1 && key[len(key)-1] == 'A' {
+		key = key[:len(key)-1]
+	}
+
+	if len(key) > 6 {
+		key = key[:6]
+	}
+	return string(key)
+}
+
+// isPhoneticVowel reports whether c is A, E, I, O or U
+func isPhoneticVowel(c byte) bool {
+	return strings.IndexByte("AEIOU", c) >= 0
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"123", ""},
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Lee", "L000"},
+		{"Lloyd", "L300"},
+		{"Jackson", "J250"},
+		{"Washington", "W252"},
+		{"Gutierrez", "G362"},
+		// H and W don't separate letters with the same code
+		{"Ashcraft", "A261"},
+		{"Ashcroft", "A261"},
+		// vowels do
+		{"Tymczak", "T522"},
+		// the first letter's code isn't repeated
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"O'Hara", "O600"},
+	}
+	for _, tt := range tests {
+		if got := Soundex(tt.in); got != tt.want {
+			t.Errorf("Soundex(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNYSIIS(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"Brian", "BRAN"},
+		{"Brown", "BRAN"},
+		{"Bishop", "BASAP"},
+		{"Carlson", "CARLSA"},
+		{"Kassner", "CASNAR"},
+		{"Knight", "NAGT"},
+		{"Larson", "LARSAN"},
+		{"Macintosh", "MCANT"},
+		{"Mitchell", "MATCAL"},
+		{"Phillips", "FALAP"},
+		{"Schoenhoeft", "SANAFT"},
+		{"Watkins", "WATCAN"},
+		{"Wheeler", "WALAR"},
+	}
+	for _, tt := range tests {
+		if got := NYSIIS(tt.in); got != tt.want {
+			t.Errorf("NYSIIS(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPhoneticKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"Smith", []string{"~SM0", "~XMT"}},
+		{"Smyth", []string{"~SM0", "~XMT"}},
+		{"Knight", []string{"~NT"}},
+	}
+	for _, tt := range tests {
+		if got := PhoneticKeys(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("PhoneticKeys(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
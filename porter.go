@@ -0,0 +1,221 @@
+package utils
+
+// porterStep is a suffix and its replacement in one step of PorterStem
+type porterStep struct {
+	suffix, replacement string
+}
+
+var porterStep2 = []porterStep{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+	{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+	{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"},
+	{"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+var porterStep3 = []porterStep{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var porterStep4 = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent",
+	"ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+/*
+PorterStem returns the stem of an English word by Martin Porter's
+algorithm, so "connected", "connecting" and "connection" all give
+"connect". Stems aren't always words ("happy" => "happi"); they are for
+matching, not display. *word* should be lower case; words with anything
+other than the letters a-z, or of two letters or fewer, are returned
+unchanged.
+*/
+func PorterStem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.replace(porterStep2)
+		p.replace(porterStep3)
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// porter is a word being stemmed: b[0..k] is the word so far, and b[0..j]
+// the stem before the suffix last found by ends
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// consonant reports whether b[i] is a consonant; y is one after a vowel
+func (p *porter) consonant(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.consonant(i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in b[0..j]
+func (p *porter) measure() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.consonant(i); i++ {
+	}
+	for i <= p.j {
+		for ; i <= p.j && !p.consonant(i); i++ {
+		}
+		if i > p.j {
+			break
+		}
+		n++
+		for ; i <= p.j && p.consonant(i); i++ {
+		}
+	}
+	return n
+}
+
+// vowelInStem reports whether b[0..j] has a vowel
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.consonant(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleConsonant reports whether b[i-1..i] is a doubled consonant
+func (p *porter) doubleConsonant(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.consonant(i)
+}
+
+// cvc reports whether b[i-2..i] is consonant-vowel-consonant with the
+// last not w, x or y, as in "hop" but not "snow"
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.consonant(i) || p.consonant(i-1) || !p.consonant(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends reports whether b[0..k] ends with *suffix*, setting j before it
+func (p *porter) ends(suffix string) bool {
+	if len(suffix) > p.k+1 || string(p.b[p.k+1-len(suffix):p.k+1]) != suffix {
+		return false
+	}
+	p.j = p.k - len(suffix)
+	return true
+}
+
+// setTo replaces b[j+1..k] with *s*
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// replace applies the first of *steps* whose suffix the word ends with,
+// if the stem before it has a vowel-consonant sequence
+func (p *porter) replace(steps []porterStep) {
+	for _, s := range steps {
+		if p.ends(s.suffix) {
+			if p.measure() > 0 {
+				p.setTo(s.replacement)
+			}
+			return
+		}
+	}
+}
+
+// step1ab removes plurals and -ed or -ing
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.measure() > 0 {
+			p.k--
+		}
+		return
+	}
+	if !(p.ends("ed") || p.ends("ing")) || !p.vowelInStem() {
+		return
+	}
+
+	p.k = p.j
+	switch {
+	case p.ends("at"):
+		p.setTo("ate")
+	case p.ends("bl"):
+		p.setTo("ble")
+	case p.ends("iz"):
+		p.setTo("ize")
+	case p.doubleConsonant(p.k):
+		if c := p.b[p.k]; c != 'l' && c != 's' && c != 'z' {
+			p.k--
+		}
+	case p.measure() == 1 && p.cvc(p.k):
+		p.setTo("e")
+	}
+}
+
+// step1c turns a final y into i after a vowel in the stem
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// step4 removes -ant, -ence and the like after a longer stem
+func (p *porter) step4() {
+	for _, suffix := range porterStep4 {
+		if !p.ends(suffix) {
+			continue
+		}
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			continue
+		}
+		if p.measure() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 removes a final -e and undoubles a final -ll after a longer stem
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		if m := p.measure(); m > 1 || (m == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doubleConsonant(p.k) && p.measure() > 1 {
+		p.k--
+	}
+}
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SanitizePolicy is an allowlist for SanitizeHTML: anything not listed is
+// removed
+type SanitizePolicy struct {
+	// Tags maps each allowed element to its allowed attributes. Other
+	// elements are removed but their text kept, except for scripts,
+	// styles and the like, which are removed whole.
+	Tags map[string][]string
+
+	// URLSchemes are allowed in href and src attributes; relative URLs
+	// are always allowed. An attribute with any other scheme is removed.
+	URLSchemes []string
+
+	// NoRel leaves links' rel attributes as they are, rather than
+	// setting rel="nofollow noopener"
+	NoRel bool
+}
+
+// DefaultSanitizePolicy allows basic formatting, lists, tables and links
+// to web and mail addresses: enough for a pasted seminar abstract
+var DefaultSanitizePolicy = SanitizePolicy{
+	Tags: map[string][]string{
+		"a": {"href", "title"}, "abbr": {"title"}, "b": nil, "blockquote": {"cite"}, "br": nil,
+		"code": nil, "dd": nil, "div": nil, "dl": nil, "dt": nil, "em": nil, "h1": nil, "h2": nil,
+		"h3": nil, "h4": nil, "h5": nil, "h6": nil, "hr": nil, "i": nil, "li": nil, "ol": {"start"},
+		"p": nil, "pre": nil, "q": {"cite"}, "s": nil, "small": nil, "span": nil, "strong": nil,
+		"sub": nil, "sup": nil, "table": nil, "tbody": nil, "td": {"colspan", "rowspan"},
+		"tfoot": nil, "th": {"colspan", "rowspan", "scope"}, "thead": nil, "tr": nil, "u": nil,
+		"ul": nil,
+	},
+	URLSchemes: []string{"http", "https", "mailto"},
+}
+
+// sanitizeDropped elements are removed with their content
+var sanitizeDropped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Select: true,
+	atom.Head: true, atom.Title: true, atom.Frameset: true, atom.Frame: true, atom.Applet: true,
+	atom.Math: true, atom.Svg: true,
+}
+
+// sanitizeURLAttributes hold URLs to check against URLSchemes
+var sanitizeURLAttributes = map[string]bool{
+	"href": true, "src": true, "cite": true, "action": true, "formaction": true, "poster": true,
+	"background": true, "longdesc": true, "usemap": true,
+}
+
+// SanitizeHTML makes untrusted HTML safe to show by
+// DefaultSanitizePolicy; see SanitizePolicy.Sanitize
+func SanitizeHTML(s string) string {
+	return DefaultSanitizePolicy.Sanitize(s)
+}
+
+/*
+Sanitize returns the HTML fragment *s* with only the elements and
+attributes p allows. Text is re-escaped, comments are removed, URLs with
+schemes p doesn't list (javascript:, data: and the like) are removed, and
+unless NoRel is set, links get rel="nofollow noopener". Unclosed tags are
+closed, so the result can't break the page around it.
+*/
+func (p SanitizePolicy) Sanitize(s string) string {
+	context := &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(s), context)
+	if err != nil {
+		return html.EscapeString(s)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		p.render(&b, n)
+	}
+	return b.String()
+}
+
+func (p SanitizePolicy) render(b *strings.Builder, n *nethtml.Node) {
+	switch n.Type {
+	case nethtml.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case nethtml.ElementNode:
+	default:
+		return
+	}
+
+	if sanitizeDropped[n.DataAtom] {
+		return
+	}
+
+	tag := strings.ToLower(n.Data)
+	allowed, ok := p.Tags[tag]
+	if ok {
+		b.WriteString("<" + tag)
+		for _, a := range p.attributes(tag, n.Attr, allowed) {
+			b.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+		}
+		b.WriteString(">")
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.render(b, c)
+	}
+
+	if ok && !isVoidElement(n.DataAtom) {
+		b.WriteString("</" + tag + ">")
+	}
+}
+
+// attributes returns the allowed attributes of an element, with URLs
+// checked and rel set on links
+func (p SanitizePolicy) attributes(tag string, attrs []nethtml.Attribute, allowed []string) []nethtml.Attribute {
+	var result []nethtml.Attribute
+	for _, a := range attrs {
+		key := strings.ToLower(a.Key)
+		if a.Namespace != "" || !containsFold(allowed, key) {
+			continue
+		}
+		if key == "rel" && tag == "a" && !p.NoRel {
+			continue
+		}
+		if sanitizeURLAttributes[key] && !p.allowedURL(a.Val) {
+			continue
+		}
+		result = append(result, nethtml.Attribute{Key: key, Val: a.Val})
+	}
+
+	if tag == "a" && !p.NoRel {
+		result = append(result, nethtml.Attribute{Key: "rel", Val: "nofollow noopener"})
+	}
+	return result
+}
+
+// allowedURL reports whether *raw* is relative or has an allowed scheme.
+// Browsers ignore whitespace and control characters in schemes
+// ("java\tscript:"), so those are removed before checking.
+func (p SanitizePolicy) allowedURL(raw string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// A colon before any slash would be read as a scheme
+		before, _, _ := strings.Cut(cleaned, "/")
+		return !strings.Contains(before, ":")
+	}
+	return containsFold(p.URLSchemes, u.Scheme)
+}
+
+// isVoidElement reports whether an element has no end tag
+func isVoidElement(a atom.Atom) bool {
+	switch a {
+	case atom.Area, atom.Base, atom.Br, atom.Col, atom.Embed, atom.Hr, atom.Img, atom.Input,
+		atom.Link, atom.Meta, atom.Source, atom.Track, atom.Wbr:
+		return true
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
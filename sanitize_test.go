@@ -0,0 +1,60 @@
+package utils
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{`<p>1 < 2 & "q"</p>`, "<p>1 &lt; 2 &amp; &#34;q&#34;</p>"},
+		{`<b>unclosed`, "<b>unclosed</b>"},
+		{`<p>a<!-- comment -->b</p>`, "<p>ab</p>"},
+		{`<div><span>x</span><custom>y</custom></div>`, "<div><span>x</span>y</div>"},
+
+		// allowed URLs are kept and links get rel
+		{`<a href="/relative">x</a>`, `<a href="/relative" rel="nofollow noopener">x</a>`},
+		{`<a href="mailto:a@b.c" title="t">x</a>`, `<a href="mailto:a@b.c" title="t" rel="nofollow noopener">x</a>`},
+		{`<a href="https://example.com/a?b=1&amp;c=2">x</a>`, `<a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener">x</a>`},
+
+		// script URLs however they're spelled
+		{`<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{`<a href="JaVaScRiPt:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{"<a href=\"java\tscript:alert(1)\">x</a>", `<a rel="nofollow noopener">x</a>`},
+		{`<a href="java&#x09;script:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{`<a href="&#106;avascript:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{`<a href=" javascript:alert(1)">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{`<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, `<a rel="nofollow noopener">x</a>`},
+		{`<blockquote cite="data:text/plain,x">q</blockquote>`, "<blockquote>q</blockquote>"},
+
+		// attributes the policy doesn't list
+		{`<a href="/x" rel="opener" target="_blank" onclick="x()">x</a>`, `<a href="/x" rel="nofollow noopener">x</a>`},
+		{`<p onmouseover="x()" style="color:red" class="c">hi</p>`, "<p>hi</p>"},
+		{`<img src=x onerror=alert(1)>`, ""},
+
+		// scripts inside foreign content and noscript
+		{`<svg><script>alert(1)</script>text</svg>after`, "after"},
+		{`<math><mtext><script>alert(1)</script></mtext></math>after`, "after"},
+		{`<noscript><p title="</noscript><img src=x onerror=alert(1)>"></noscript>`, "&#34;&gt;"},
+		{`<script>alert(1)</script><style>p{}</style>ok`, "ok"},
+	}
+	for _, tt := range tests {
+		if got := SanitizeHTML(tt.in); got != tt.want {
+			t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizePolicyNoRel(t *testing.T) {
+	p := SanitizePolicy{
+		Tags:       map[string][]string{"a": {"href", "rel"}},
+		URLSchemes: []string{"https"},
+		NoRel:      true,
+	}
+	in := `<a href="/x" rel="me" target="_blank">x</a><a href="mailto:a@b.c">y</a>`
+	want := `<a href="/x" rel="me">x</a><a>y</a>`
+	if got := p.Sanitize(in); got != want {
+		t.Errorf("Sanitize(%q) = %q, want %q", in, got, want)
+	}
+}
@@ -0,0 +1,222 @@
+// Package search is an in-memory inverted index over documents with
+// named text fields. Fields are tokenized into words and short phrases,
+// the way utils.TokenizeWith splits them for datastore list properties;
+// queries support prefixes, phrases, AND/OR and field restrictions, and
+// results are ranked with BM25 and per-field boosts.
+package search
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/bjbigler/utils"
+)
+
+// Document is a unit of search: an ID and its text by field name
+type Document struct {
+	ID     string            `json:"id"`
+	Fields map[string]string `json:"fields"`
+}
+
+// Options configures an Index. The zero value is usable.
+type Options struct {
+	// Boosts multiplies the score of matches in a field; fields not
+	// listed count 1
+	Boosts map[string]float64 `json:"boosts"`
+
+	// MaxPhraseWords is the longest phrase indexed; 0 means 3
+	MaxPhraseWords int `json:"max_phrase_words"`
+
+	// Phonetic indexes each word's utils.PhoneticKeys, and queries match
+	// them at half weight, so "smyth" finds "Smith"
+	Phonetic bool `json:"phonetic"`
+
+	// K1 and B are the BM25 term-frequency saturation and length
+	// normalization parameters; 0 means the usual 1.2 and 0.75
+	K1 float64 `json:"k1"`
+	B  float64 `json:"b"`
+}
+
+// Result is one ranked search hit
+type Result struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// document is an indexed Document with its field lengths in words
+type document struct {
+	source  Document
+	lengths map[string]int
+	terms   []string
+}
+
+/*
+Index is an inverted index safe for concurrent use: searches run in
+parallel with each other, and Add and Remove wait for running searches
+and block new ones only while they update the index.
+*/
+type Index struct {
+	mu       sync.RWMutex
+	options  Options
+	docs     map[string]*document
+	postings map[string]map[string]map[string]int // term => doc ID => field => count
+	vocab    []string                             // sorted terms, for prefix queries
+	dirty    bool                                 // vocab needs rebuilding
+	totals   map[string]int                       // words per field across all documents
+}
+
+// New returns an empty index
+func New(options Options) *Index {
+	if options.MaxPhraseWords <= 0 {
+		options.MaxPhraseWords = 3
+	}
+	if options.K1 <= 0 {
+		options.K1 = 1.2
+	}
+	if options.B <= 0 {
+		options.B = 0.75
+	}
+
+	return &Index{
+		options:  options,
+		docs:     make(map[string]*document),
+		postings: make(map[string]map[string]map[string]int),
+		totals:   make(map[string]int),
+	}
+}
+
+// Len returns the number of documents in the index
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Get returns the document with *id*, if it is indexed
+func (ix *Index) Get(id string) (Document, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	if d, ok := ix.docs[id]; ok {
+		return d.source, true
+	}
+	return Document{}, false
+}
+
+// Add indexes documents, replacing any already indexed with the same ID
+func (ix *Index) Add(docs ...Document) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	for _, doc := range docs {
+		ix.remove(doc.ID)
+		ix.add(doc)
+	}
+}
+
+// Remove drops the document with *id*, reporting whether it was indexed
+func (ix *Index) Remove(id string) bool {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.remove(id)
+}
+
+func (ix *Index) add(doc Document) {
+	d := &document{source: doc, lengths: make(map[string]int)}
+
+	for field, text := range doc.Fields {
+		words, terms := ix.tokenize(text)
+		d.lengths[field] = words
+		ix.totals[field] += words
+
+		for term, n := range terms {
+			byDoc, ok := ix.postings[term]
+			if !ok {
+				byDoc = make(map[string]map[string]int)
+				ix.postings[term] = byDoc
+				ix.dirty = true
+			}
+			if byDoc[doc.ID] == nil {
+				byDoc[doc.ID] = make(map[string]int)
+				d.terms = append(d.terms, term)
+			}
+			byDoc[doc.ID][field] += n
+		}
+	}
+
+	ix.docs[doc.ID] = d
+}
+
+func (ix *Index) remove(id string) bool {
+	d, ok := ix.docs[id]
+	if !ok {
+		return false
+	}
+
+	for field, words := range d.lengths {
+		ix.totals[field] -= words
+	}
+	for _, term := range d.terms {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+			ix.dirty = true
+		}
+	}
+
+	delete(ix.docs, id)
+	return true
+}
+
+// tokenize returns the number of words in text and how often each term
+// occurs: words and phrases of up to MaxPhraseWords words, as
+// utils.TokenizeWith folds and splits them, plus phonetic keys if enabled.
+// A hyphenated word is also indexed by its parts, so "smith" finds
+// "O'Brien-Smith"; the parts don't add to the word count.
+func (ix *Index) tokenize(text string) (int, map[string]int) {
+	counts := utils.TokenCounts(utils.TokenizeOptions{
+		FoldCase:       true,
+		FoldAccents:    true,
+		MaxPhraseWords: ix.options.MaxPhraseWords,
+		Phonetic:       ix.options.Phonetic,
+		NoFragments:    true,
+	}, text)
+
+	words := 0
+	parts := make(map[string]int)
+	for term, n := range counts {
+		if strings.Contains(term, " ") || strings.HasPrefix(term, "~") {
+			continue
+		}
+		words += n
+
+		if !strings.Contains(term, "-") {
+			continue
+		}
+		for _, part := range strings.Split(term, "-") {
+			if part != "" && part != term {
+				parts[part] += n
+			}
+		}
+	}
+	for part, n := range parts {
+		counts[part] += n
+	}
+	return words, counts
+}
+
+// normalize folds and splits query text the way tokenize does, so
+// "José O'Brien" => "jose o'brien"
+func normalize(text string) string {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		tokens := utils.TokenizeWith(utils.TokenizeOptions{
+			FoldCase:       true,
+			FoldAccents:    true,
+			MaxPhraseWords: 1,
+			NoFragments:    true,
+		}, word)
+		words = append(words, tokens...)
+	}
+	return strings.Join(words, " ")
+}
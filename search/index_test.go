@@ -0,0 +1,118 @@
+package search
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func testIndex(options Options) *Index {
+	ix := New(options)
+	ix.Add(
+		Document{ID: "1", Fields: map[string]string{"name": "José O'Brien-Smith", "city": "Los Angeles"}},
+		Document{ID: "2", Fields: map[string]string{"name": "John Smith", "city": "New York"}},
+		Document{ID: "3", Fields: map[string]string{"name": "Katherine Jones", "city": "Los Alamos"}},
+	)
+	return ix
+}
+
+func ids(results []Result) []string {
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	ix := testIndex(Options{Phonetic: true})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"jose", []string{"1"}},
+		{"JOSÉ", []string{"1"}},
+		{"o’brien-smith", []string{"1"}},
+		{"o'brien*", []string{"1"}},
+		{"smith", []string{"2", "1"}},
+		{"o'brien", []string{"1"}},
+		{"smi*", []string{"1", "2"}},
+		{`"los angeles"`, []string{"1"}},
+		{"los al*", []string{"3"}},
+		{"city:york OR jones", []string{"2", "3"}},
+		{"name:john", []string{"2"}},
+		{"city:john", nil},
+		{"catherine", []string{"3"}},
+		{"nobody", nil},
+	}
+	for _, tt := range tests {
+		got, err := ix.Search(tt.query, 0)
+		if err != nil {
+			t.Errorf("Search(%q): %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(ids(got), tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, ids(got), tt.want)
+		}
+	}
+}
+
+func TestTermCounts(t *testing.T) {
+	ix := New(Options{})
+	ix.Add(Document{ID: "1", Fields: map[string]string{"text": "to be, or not to be"}})
+
+	if got := ix.docs["1"].lengths["text"]; got != 6 {
+		t.Errorf("words = %d, want 6", got)
+	}
+	for term, want := range map[string]int{"to": 2, "be": 2, "to be": 2, "or not to": 1} {
+		if got := ix.postings[term]["1"]["text"]; got != want {
+			t.Errorf("count of %q = %d, want %d", term, got, want)
+		}
+	}
+}
+
+func TestRemoveAndSnapshot(t *testing.T) {
+	ix := testIndex(Options{})
+	if !ix.Remove("2") || ix.Remove("2") {
+		t.Fatal("Remove should succeed once")
+	}
+
+	var buf bytes.Buffer
+	if err := ix.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{"smith", "los", "john"} {
+		want, _ := ix.Search(query, 0)
+		got, _ := loaded.Search(query, 0)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("loaded Search(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	ix := testIndex(Options{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ix.Add(Document{ID: "4", Fields: map[string]string{"name": "Jo Smith"}})
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := ix.Search("smith OR jo*", 0); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
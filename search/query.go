@@ -0,0 +1,314 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bjbigler/utils"
+)
+
+// Term is one query term. Text is normalized like indexed text: lower
+// case, accents folded, one word or a phrase.
+type Term struct {
+	Field  string `json:"field"`  // restrict to this field, or "" for any
+	Text   string `json:"text"`   // word or phrase
+	Prefix bool   `json:"prefix"` // match indexed terms starting with Text
+}
+
+// Query is a parsed search. It matches documents that match all the terms
+// of any one of its groups.
+type Query struct {
+	Groups [][]Term `json:"groups"`
+}
+
+/*
+ParseQuery parses a search string. Terms separated by spaces must all
+match, and OR separates alternatives: `smith john OR smyth` finds
+documents with both "smith" and "john", or with "smyth". A trailing *
+makes a prefix term (jo*), quotes make a phrase ("los angeles"), and
+field: restricts a term to one field (title:budget, speaker:"ann lee").
+AND may be written between terms but is implied.
+*/
+func ParseQuery(query string) (Query, error) {
+	words, err := splitQuery(query)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	var group []Term
+	for i, word := range words {
+		switch word {
+		case "AND":
+			if len(group) == 0 || i == len(words)-1 {
+				return Query{}, fmt.Errorf(`search query "%s" has AND without a term on each side`, query)
+			}
+			continue
+		case "OR":
+			if len(group) == 0 || i == len(words)-1 {
+				return Query{}, fmt.Errorf(`search query "%s" has OR without a term on each side`, query)
+			}
+			q.Groups = append(q.Groups, group)
+			group = nil
+			continue
+		}
+
+		terms, err := parseTerm(word)
+		if err != nil {
+			return Query{}, err
+		}
+		group = append(group, terms...)
+	}
+	if len(group) > 0 {
+		q.Groups = append(q.Groups, group)
+	}
+
+	if len(q.Groups) == 0 {
+		return Query{}, fmt.Errorf(`search query "%s" has no terms`, query)
+	}
+	return q, nil
+}
+
+// splitQuery splits on spaces outside quotes, keeping the quotes
+func splitQuery(query string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf(`search query "%s" has an unclosed quote`, query)
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+// parseTerm parses [field:]word[*] or [field:]"phrase". Punctuation
+// inside a word stays, as in indexed text: "smith-jones" is one word.
+func parseTerm(word string) ([]Term, error) {
+	var field string
+	if i := strings.Index(word, ":"); i > 0 && !strings.Contains(word[:i], `"`) {
+		field, word = word[:i], word[i+1:]
+	}
+
+	prefix := false
+	if strings.HasPrefix(word, `"`) {
+		word = strings.Trim(word, `"`)
+	} else if strings.HasSuffix(word, "*") {
+		word = strings.TrimRight(word, "*")
+		prefix = true
+	}
+
+	text := normalize(word)
+	if text == "" {
+		if field != "" {
+			return nil, fmt.Errorf(`search term "%s:" has no text`, field)
+		}
+		return nil, nil
+	}
+
+	return []Term{{Field: field, Text: text, Prefix: prefix}}, nil
+}
+
+// Search parses *query* and returns up to *limit* results, best first;
+// limit 0 returns every match
+func (ix *Index) Search(query string, limit int) ([]Result, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return ix.SearchQuery(q, limit), nil
+}
+
+// SearchQuery runs a parsed query; see Search
+func (ix *Index) SearchQuery(q Query, limit int) []Result {
+	ix.refreshVocab()
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := make(map[string]float64)
+	for _, group := range q.Groups {
+		for id, score := range ix.groupScores(group) {
+			scores[id] += score
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, Result{ID: id, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// groupScores scores the documents matching every term of a group
+func (ix *Index) groupScores(group []Term) map[string]float64 {
+	var scores map[string]float64
+	for _, term := range ix.fitPhrases(group) {
+		termScores := ix.termScores(term)
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for id := range scores {
+			if s, ok := termScores[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores
+}
+
+// fitPhrases replaces phrases longer than the index keeps with the
+// overlapping phrases that make them up
+func (ix *Index) fitPhrases(group []Term) []Term {
+	maxWords := ix.options.MaxPhraseWords
+
+	var result []Term
+	for _, term := range group {
+		words := strings.Fields(term.Text)
+		if len(words) <= maxWords {
+			result = append(result, term)
+			continue
+		}
+		for start := 0; start+maxWords <= len(words); start++ {
+			result = append(result, Term{Field: term.Field, Text: strings.Join(words[start:start+maxWords], " ")})
+		}
+	}
+	return result
+}
+
+// expansion is an indexed term a query term matches, with its weight
+type expansion struct {
+	term   string
+	weight float64
+}
+
+// termScores scores the documents matching one term by BM25, taking the
+// best of its expansions
+func (ix *Index) termScores(t Term) map[string]float64 {
+	scores := make(map[string]float64)
+	n := float64(len(ix.docs))
+
+	for _, e := range ix.expand(t) {
+		byDoc := ix.postings[e.term]
+		df := float64(len(byDoc))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for id, fields := range byDoc {
+			score := 0.0
+			for field, tf := range fields {
+				if t.Field != "" && !strings.EqualFold(field, t.Field) {
+					continue
+				}
+				score += ix.boost(field) * idf * ix.saturate(float64(tf), id, field)
+			}
+			score *= e.weight
+			if score > 0 && score > scores[id] {
+				scores[id] = score
+			}
+		}
+	}
+	return scores
+}
+
+// saturate is BM25's term-frequency component for a field of a document
+func (ix *Index) saturate(tf float64, id, field string) float64 {
+	k1, b := ix.options.K1, ix.options.B
+
+	length := float64(ix.docs[id].lengths[field])
+	average := float64(ix.totals[field]) / float64(len(ix.docs))
+	if average == 0 {
+		average = 1
+	}
+	return tf * (k1 + 1) / (tf + k1*(1-b+b*length/average))
+}
+
+func (ix *Index) boost(field string) float64 {
+	if boost, ok := ix.options.Boosts[field]; ok {
+		return boost
+	}
+	return 1
+}
+
+// expand lists the indexed terms t matches: itself, every term it
+// prefixes, and at half weight its phonetic keys
+func (ix *Index) expand(t Term) []expansion {
+	if !t.Prefix {
+		expansions := []expansion{{term: t.Text, weight: 1}}
+		if ix.options.Phonetic && !strings.Contains(t.Text, " ") {
+			for _, key := range utils.PhoneticKeys(t.Text) {
+				expansions = append(expansions, expansion{term: key, weight: 0.5})
+			}
+		}
+		return expansions
+	}
+
+	var expansions []expansion
+	if ix.dirty {
+		// An update since the vocabulary was sorted; scan the postings
+		for term := range ix.postings {
+			if strings.HasPrefix(term, t.Text) {
+				expansions = append(expansions, expansion{term: term, weight: 1})
+			}
+		}
+		return expansions
+	}
+
+	for i := sort.SearchStrings(ix.vocab, t.Text); i < len(ix.vocab) && strings.HasPrefix(ix.vocab[i], t.Text); i++ {
+		expansions = append(expansions, expansion{term: ix.vocab[i], weight: 1})
+	}
+	return expansions
+}
+
+// refreshVocab re-sorts the vocabulary after updates
+func (ix *Index) refreshVocab() {
+	ix.mu.RLock()
+	dirty := ix.dirty
+	ix.mu.RUnlock()
+	if !dirty {
+		return
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if !ix.dirty {
+		return
+	}
+
+	ix.vocab = ix.vocab[:0]
+	for term := range ix.postings {
+		ix.vocab = append(ix.vocab, term)
+	}
+	sort.Strings(ix.vocab)
+	ix.dirty = false
+}
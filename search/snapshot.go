@@ -0,0 +1,80 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// snapshotVersion is the format version written by Save
+const snapshotVersion = 1
+
+// snapshot is the saved form of an Index: its options and documents. The
+// postings are rebuilt on Load.
+type snapshot struct {
+	Version   int        `json:"version"`
+	Options   Options    `json:"options"`
+	Documents []Document `json:"documents"`
+}
+
+// Save writes the index's options and documents to w as JSON. It holds
+// the index's read lock only while copying the document list.
+func (ix *Index) Save(w io.Writer) error {
+	ix.mu.RLock()
+	snap := snapshot{Version: snapshotVersion, Options: ix.options, Documents: make([]Document, 0, len(ix.docs))}
+	for _, d := range ix.docs {
+		snap.Documents = append(snap.Documents, d.source)
+	}
+	ix.mu.RUnlock()
+
+	sort.Slice(snap.Documents, func(i, j int) bool { return snap.Documents[i].ID < snap.Documents[j].ID })
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// SaveFile writes a snapshot to *path*, replacing it only once the new
+// snapshot is completely written
+func (ix *Index) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := ix.Save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads a snapshot written by Save and rebuilds its index
+func Load(r io.Reader) (*Index, error) {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("reading search snapshot: %w", err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("search snapshot version %d not supported", snap.Version)
+	}
+
+	ix := New(snap.Options)
+	ix.Add(snap.Documents...)
+	return ix, nil
+}
+
+// LoadFile reads a snapshot written by SaveFile
+func LoadFile(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
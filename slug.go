@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Slugify makes a URL slug of *s*: letters transliterated to Latin and
+lower-cased, runs of anything but letters and digits replaced by one
+*separator* ("" means "-"), and apostrophes dropped, so "Åsa O'Brien: Straße
+& Москва" => "asa-obrien-strasse-moskva". A *maxLength* above 0 cuts the
+slug at the last word boundary that fits, or mid-word if the first word
+alone is too long.
+*/
+func Slugify(s string, separator string, maxLength int) string {
+	if separator == "" {
+		separator = "-"
+	}
+
+	var words []string
+	var word strings.Builder
+	for _, r := range strings.ToLower(Transliterate(s)) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			word.WriteRune(r)
+		case r == '\'' || r == '’':
+		default:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	slug := strings.Join(words, separator)
+	if maxLength <= 0 || len(slug) <= maxLength {
+		return slug
+	}
+
+	// A separator right after the limit still marks a boundary that fits
+	end := min(maxLength+len(separator), len(slug))
+	if cut := strings.LastIndex(slug[:end], separator); cut > 0 {
+		return slug[:cut]
+	}
+	return slug[:maxLength]
+}
+
+// UniqueSlug returns *base*, or if *exists* reports it taken, base-2,
+// base-3 and so on until one is free, like getUniqueFilename does for
+// downloads
+func UniqueSlug(base string, exists func(string) bool) string {
+	if !exists(base) {
+		return base
+	}
+
+	for i := 2; ; i++ {
+		slug := fmt.Sprintf("%s-%d", base, i)
+		if !exists(slug) {
+			return slug
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in        string
+		separator string
+		maxLength int
+		want      string
+	}{
+		{"Åsa O'Brien: Straße & Москва", "", 0, "asa-obrien-strasse-moskva"},
+		{"  --Hello, World!!  ", "", 0, "hello-world"},
+		{"Introduction to Graduate Seminars", "_", 20, "introduction_to"},
+		{"Supercalifragilistic", "", 10, "supercalif"},
+		{"abc def", "", 3, "abc"},
+		{"abc def", "", 4, "abc"},
+		{"ab cd", "--", 5, "ab"},
+		{"ab cd", "--", 6, "ab--cd"},
+		{"ab cd ef", "--", 7, "ab--cd"},
+		{"ab cd", "·", 4, "ab"},
+		// decomposed input spells umlauts as precomposed input does
+		{"Mu\u0308ller", "", 0, "mueller"},
+		{"Müller", "", 0, "mueller"},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.in, tt.separator, tt.maxLength); got != tt.want {
+			t.Errorf("Slugify(%q, %q, %d) = %q, want %q", tt.in, tt.separator, tt.maxLength, got, tt.want)
+		}
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	taken := map[string]bool{"talk": true, "talk-2": true}
+	exists := func(s string) bool { return taken[s] }
+
+	if got := UniqueSlug("talk", exists); got != "talk-3" {
+		t.Errorf(`UniqueSlug("talk") = %q, want "talk-3"`, got)
+	}
+	if got := UniqueSlug("panel", exists); got != "panel" {
+		t.Errorf(`UniqueSlug("panel") = %q, want "panel"`, got)
+	}
+}
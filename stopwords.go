@@ -0,0 +1,43 @@
+package utils
+
+// StopWords are common words by ISO 639-1 language code, for
+// TokenizeOptions.StopWords. They are lower case with their accents.
+var StopWords = map[string][]string{
+	"en": {
+		"a", "about", "after", "all", "also", "an", "and", "any", "are", "as", "at", "be", "been",
+		"but", "by", "can", "could", "did", "do", "does", "for", "from", "had", "has", "have", "he",
+		"her", "his", "how", "i", "if", "in", "into", "is", "it", "its", "may", "more", "no", "not",
+		"of", "on", "or", "our", "she", "so", "such", "than", "that", "the", "their", "them", "then",
+		"there", "these", "they", "this", "those", "to", "too", "up", "was", "we", "were", "what",
+		"when", "which", "who", "will", "with", "would", "you", "your",
+	},
+	"es": {
+		"a", "al", "algo", "como", "con", "de", "del", "el", "ella", "ellos", "en", "entre", "era",
+		"es", "esta", "este", "esto", "fue", "ha", "hay", "la", "las", "le", "les", "lo", "los", "más",
+		"me", "mi", "muy", "no", "nos", "o", "para", "pero", "por", "que", "se", "si", "sin", "sobre",
+		"su", "sus", "también", "te", "tu", "un", "una", "uno", "y", "ya", "yo",
+	},
+	"fr": {
+		"à", "au", "aux", "avec", "ce", "ces", "dans", "de", "des", "du", "elle", "en", "est", "et",
+		"eux", "il", "ils", "je", "la", "le", "les", "leur", "lui", "ma", "mais", "me", "mes", "moi",
+		"mon", "ne", "nous", "on", "ou", "par", "pas", "pour", "qu", "que", "qui", "sa", "se", "ses",
+		"son", "sur", "ta", "te", "tes", "toi", "ton", "tu", "un", "une", "vous", "y",
+	},
+	"de": {
+		"aber", "als", "am", "an", "auch", "auf", "aus", "bei", "bin", "bis", "das", "dass", "dem",
+		"den", "der", "des", "die", "du", "ein", "eine", "einem", "einen", "einer", "er", "es", "für",
+		"hat", "ich", "ihr", "im", "in", "ist", "mit", "nach", "nicht", "noch", "oder", "sie", "sind",
+		"so", "über", "um", "und", "von", "vor", "war", "wie", "wir", "zu", "zum", "zur",
+	},
+	"it": {
+		"a", "al", "alla", "anche", "che", "chi", "con", "da", "dal", "del", "della", "di", "e", "è",
+		"gli", "ha", "i", "il", "in", "la", "le", "lo", "ma", "mi", "ne", "nel", "non", "o", "per",
+		"più", "se", "si", "su", "sono", "tra", "un", "una", "uno",
+	},
+	"pt": {
+		"a", "ao", "as", "com", "como", "da", "das", "de", "do", "dos", "e", "é", "ela", "ele", "em",
+		"entre", "era", "eu", "foi", "isso", "já", "mais", "mas", "na", "nas", "no", "nos", "não",
+		"o", "os", "ou", "para", "pela", "pelo", "por", "que", "se", "sem", "seu", "sua", "também",
+		"um", "uma",
+	},
+}
@@ -3,13 +3,14 @@ package utils
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
-	"golang.org/x/text/runes"
+	"golang.org/x/text/cases"
 	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
 )
 
 // Tokenize returns a slice of unique "words" of *minLength*
@@ -106,6 +107,199 @@ func TokenizeWords(term string) (result []string) {
 	return result
 }
 
+// TokenizeOptions configures TokenizeWith. The zero value tokenizes
+// much as Tokenize does, with these differences: words are trimmed of
+// punctuation at their ends ("Smith," => "smith"); phrases are emitted
+// from two words up, single words being emitted as words; runs of
+// spaces count as one; MinLength is counted in runes, where Tokenize
+// skips terms by bytes; no empty fragment is emitted; and the result is
+// sorted.
+type TokenizeOptions struct {
+	MinLength int  // shortest fragment, as in Tokenize
+	Phonetic  bool // also emit each word's phonetic keys (see PhoneticKeys)
+
+	// FoldCase applies Unicode case folding to fragments as well as
+	// words and phrases, so "STRASSE" and "straße" give the same tokens
+	FoldCase bool
+
+	// FoldAccents applies ReplaceAccents, so "José" and "Jose" match
+	FoldAccents bool
+
+	// StopWords are left out as words and as the first or last word of
+	// a phrase, e.g. StopWords["en"]
+	StopWords []string
+
+	// Stem reduces the words of words and phrases with PorterStem, so
+	// "running" and "runs" match; for English text
+	Stem bool
+
+	// MaxPhraseWords is the longest phrase emitted; 0 means no limit
+	MaxPhraseWords int
+
+	// MaxTokens caps the result. Words are kept first, then phrases from
+	// shortest to longest, then phonetic keys, then fragments from
+	// shortest to longest. 0 means no limit.
+	MaxTokens int
+
+	// NoFragments leaves out the prefix fragments, keeping only words,
+	// phrases and phonetic keys
+	NoFragments bool
+}
+
+/*
+TokenizeWith is Tokenize with options. Words are split on spaces and
+trimmed of punctuation at their ends, and the result is sorted so the
+same input always gives the same tokens, even when MaxTokens cuts it
+short. With Phonetic set, each word is also encoded with PhoneticKeys
+after folding accents, so a search for "Smyth" finds "Smith" and
+"Katherine" finds "Catherine" when queried the same way.
+*/
+func TokenizeWith(opts TokenizeOptions, terms ...string) []string {
+	result := budgetTokens(tokenTiers(opts, terms), opts.MaxTokens)
+	sort.Strings(result)
+	return result
+}
+
+// TokenCounts is TokenizeWith that also counts how often each token
+// occurs in *terms*, for ranking: "to be or not to be" gives "to" and
+// "to be" 2 each
+func TokenCounts(opts TokenizeOptions, terms ...string) map[string]int {
+	tiers := tokenTiers(opts, terms)
+
+	counts := make(map[string]int)
+	for _, token := range budgetTokens(tiers, opts.MaxTokens) {
+		counts[token] = 0
+	}
+	for _, tier := range tiers {
+		for _, token := range tier {
+			if _, ok := counts[token]; ok {
+				counts[token]++
+			}
+		}
+	}
+	return counts
+}
+
+// tokenTiers returns TokenizeWith's tokens by priority for MaxTokens,
+// repeated once per occurrence: words, phrases by length, phonetic keys,
+// fragments by length
+func tokenTiers(opts TokenizeOptions, terms []string) [][]string {
+	fold := func(s string) string {
+		if opts.FoldAccents {
+			s = ReplaceAccents(s)
+		}
+		if opts.FoldCase {
+			return cases.Fold().String(s)
+		}
+		return s
+	}
+
+	stop := make(map[string]bool, len(opts.StopWords))
+	for _, word := range opts.StopWords {
+		stop[strings.ToLower(fold(word))] = true
+	}
+
+	var words, keys []string
+	var phrases, fragments [][]string
+	for _, t := range terms {
+		if utf8.RuneCountInString(t) < opts.MinLength {
+			continue
+		}
+		t = fold(t)
+
+		var stems []string
+		var stopAt []bool
+		for _, word := range strings.Fields(t) {
+			word = strings.TrimFunc(word, isWordSeparator)
+			if opts.FoldAccents {
+				word = strings.ReplaceAll(word, "’", "'")
+			}
+			word = strings.Trim(word, "'")
+			if word == "" {
+				continue
+			}
+
+			lower := strings.ToLower(word)
+			stopAt = append(stopAt, stop[lower])
+			if stop[lower] {
+				stems = append(stems, lower)
+				continue
+			}
+			if opts.Phonetic {
+				keys = append(keys, PhoneticKeys(ReplaceAccents(word))...)
+			}
+			if opts.Stem {
+				lower = PorterStem(lower)
+			}
+			stems = append(stems, lower)
+			words = append(words, lower)
+		}
+
+		// Phrases can hold stop words, but not start or end with them
+		for start := range stems {
+			if stopAt[start] {
+				continue
+			}
+			for end := start + 2; end <= len(stems); end++ {
+				n := end - start
+				if opts.MaxPhraseWords > 0 && n > opts.MaxPhraseWords {
+					break
+				}
+				if stopAt[end-1] {
+					continue
+				}
+				for len(phrases) < n-1 {
+					phrases = append(phrases, nil)
+				}
+				phrases[n-2] = append(phrases[n-2], strings.Join(stems[start:end], " "))
+			}
+		}
+
+		// Fragments are prefixes of the whole term
+		if opts.NoFragments {
+			continue
+		}
+		runes := []rune(strings.Join(strings.Fields(t), " "))
+		for j := max(opts.MinLength, 1); j <= len(runes); j++ {
+			for len(fragments) < j {
+				fragments = append(fragments, nil)
+			}
+			fragments[j-1] = append(fragments[j-1], string(runes[:j]))
+		}
+	}
+
+	tiers := [][]string{words}
+	tiers = append(tiers, phrases...)
+	tiers = append(tiers, keys)
+	return append(tiers, fragments...)
+}
+
+// budgetTokens returns the distinct tokens of *tiers* in order, up to
+// *maxTokens* of them if it is above 0
+func budgetTokens(tiers [][]string, maxTokens int) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, tier := range tiers {
+		for _, token := range tier {
+			if seen[token] {
+				continue
+			}
+			if maxTokens > 0 && len(result) == maxTokens {
+				return result
+			}
+			seen[token] = true
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+// isWordSeparator reports whether r splits words for phonetic keys;
+// apostrophes stay inside names like O'Brien
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+}
+
 const letterBytes = "1234567890ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 const (
 	letterIdxBits = 6                    // 6 bits to represent a letter index
@@ -135,54 +329,32 @@ func GenerateRandomAlphaNumeric(n int) string {
 	return sb.String()
 }
 
-// ReplaceAccents replaces accented characters with close eqivalents
+// ReplaceAccents replaces accented characters with close eqivalents,
+// e.g. "José Łódź" => "Jose Lodz", and spells Latin letters that have
+// no accent to remove: "Ø" => "O", "ß" => "ss", "Æ" => "Ae". (It once
+// spelled only "æ" and "ł", keeping the others as they were.) For other
+// scripts, punctuation and German umlauts see Transliterate.
 func ReplaceAccents(input string) string {
-
-	input = strings.Replace(input, "æ", "ae", -1)
-	input = strings.Replace(input, "ł", "l", -1) //Convert Polish "l"
-
-	// isMn := func(r rune) bool {
-	// 	return unicode.Is(unicode.Mn, r) // Mn: nonspacing marks
-	// }
-
-	b := make([]byte, len(input))
-
-	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-
-	//t := transform.Chain(norm.NFD, transform.RemoveFunc(isMn), norm.NFC)
-	_, _, err := t.Transform(b, []byte(input), true)
-
+	output, _, err := transform.String(accentRemover(), input)
 	if err != nil {
 		return input
 	}
-
-	return string(b)
-
-	// t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-	// s, _, err := transform.String(t, input)
-
-	// if err != nil {
-
-	// }
-
+	return output
 }
 
 // Conjoin joins a string with commas and the conjunction,
-// e.g., ["bill", "sue", "jill"] => "bill, sue, and jill"
+// e.g., ["bill", "sue", "jill"] => "bill, sue, and jill".
+// An empty conjunction is left empty, as it always was, rather than
+// becoming ListFormatter's "and". See ListFormatter for more control.
 func Conjoin(val []string, conjunction string) string {
+	if conjunction != "" || len(val) < 2 {
+		return ListFormatter{Conjunction: conjunction}.Format(val)
+	}
 
-	lenVal := len(val)
-
-	switch lenVal {
-	case 0:
-		return ""
-	case 1:
-		return val[0]
-	case 2:
-		return strings.Join(val, fmt.Sprintf(" %s ", conjunction))
-	default:
-		return fmt.Sprintf("%s, %s %s", strings.Join(val[0:lenVal-1], ", "), conjunction, val[lenVal-1])
+	if len(val) == 2 {
+		return strings.Join(val, "  ")
 	}
+	return fmt.Sprintf("%s,  %s", strings.Join(val[:len(val)-1], ", "), val[len(val)-1])
 }
 
 // PrepCookieDomain removes http(s) from the institution's root URL
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTokenCounts(t *testing.T) {
+	opts := TokenizeOptions{FoldCase: true, FoldAccents: true, MaxPhraseWords: 2, NoFragments: true}
+
+	got := TokenCounts(opts, "To be, or not to BE")
+	want := map[string]int{"to": 2, "be": 2, "or": 1, "not": 1, "to be": 2, "be or": 1, "or not": 1, "not to": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TokenCounts = %v, want %v", got, want)
+	}
+
+	tokens := TokenizeWith(opts, "José O’Brien")
+	if want := []string{"jose", "jose o'brien", "o'brien"}; !reflect.DeepEqual(tokens, want) {
+		t.Errorf("TokenizeWith = %v, want %v", tokens, want)
+	}
+
+	opts.MaxTokens = 3
+	if got := TokenCounts(opts, "a b a"); !reflect.DeepEqual(got, map[string]int{"a": 2, "b": 1, "a b": 1}) {
+		t.Errorf("TokenCounts with MaxTokens = %v", got)
+	}
+}
+
+func TestConjoin(t *testing.T) {
+	tests := []struct {
+		val         []string
+		conjunction string
+		want        string
+	}{
+		{nil, "and", ""},
+		{[]string{"bill"}, "and", "bill"},
+		{[]string{"bill", "sue"}, "and", "bill and sue"},
+		{[]string{"bill", "sue", "jill"}, "and", "bill, sue, and jill"},
+		{[]string{"bill", "sue", "jill"}, "or", "bill, sue, or jill"},
+		{[]string{"bill"}, "", "bill"},
+		{[]string{"bill", "sue"}, "", "bill  sue"},
+		{[]string{"bill", "sue", "jill"}, "", "bill, sue,  jill"},
+	}
+	for _, tt := range tests {
+		if got := Conjoin(tt.val, tt.conjunction); got != tt.want {
+			t.Errorf("Conjoin(%q, %q) = %q, want %q", tt.val, tt.conjunction, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizeWithZeroValue(t *testing.T) {
+	// Without punctuation, doubled spaces or multi-byte runes, the zero
+	// value gives the same tokens as Tokenize
+	got := TokenizeWith(TokenizeOptions{MinLength: 2}, "our lady of Los Angeles")
+	want := Tokenize(2, "our lady of Los Angeles")
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TokenizeWith = %q, want %q", got, want)
+	}
+
+	// The documented differences
+	got = TokenizeWith(TokenizeOptions{NoFragments: true}, "Smith, John")
+	if want := []string{"john", "smith", "smith john"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TokenizeWith trimming = %q, want %q", got, want)
+	}
+	// "Zoë" is 3 runes but 4 bytes
+	if got := TokenizeWith(TokenizeOptions{MinLength: 4}, "Zoë"); len(got) != 0 {
+		t.Errorf("TokenizeWith counted MinLength in bytes: %q", got)
+	}
+	if got := Tokenize(4, "Zoë"); len(got) == 0 {
+		t.Error("Tokenize no longer counts the term's length in bytes")
+	}
+}
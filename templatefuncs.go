@@ -0,0 +1,346 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/shopspring/decimal"
+)
+
+// FuncMapOptions configures FuncMap
+type FuncMapOptions struct {
+	// Location is where times are shown and strings without a zone are
+	// read; nil means time.Local
+	Location *time.Location
+
+	// Locale is the language for list joining; see ListFormatter
+	Locale string
+
+	// Now returns the current time, for age; nil means time.Now
+	Now func() time.Time
+}
+
+/*
+FuncMap returns this package's formatters as html/template functions, so
+apps needn't each register their own wrappers:
+
+	t := template.New("page").Funcs(utils.FuncMap(utils.FuncMapOptions{Location: loc}))
+
+Arguments are taken loosely: numbers may be any integer or float type, a
+decimal.Decimal, a json.Number or a numeric string; times may be a
+time.Time, a *time.Time, a Date, Unix seconds or a date string; lists may
+be any slice. Trailing arguments are optional. A missing time (nil, a
+zero time or Date, or an empty string) shows as "", or 0 for age. A
+function given an argument it can't use returns an error, which stops
+the template.
+
+	number       {{number 12345.678}} => 12,345.68; {{number .N "#,###."}}
+	integer      {{integer 12345}} => 12,345
+	amPm         {{amPm 13}} => 1p
+	academicYear {{academicYear 2020}} => 2019-2020
+	clock        {{clock .Start}} => 🕑
+	ical         {{ical .Start}} => 20200102T150405Z
+	date         {{date .Start}} => January 2, 2020; {{date .Start "Jan 2"}}
+	isoWeek      {{isoWeek .Start}} => 2020-W01
+	age          {{age .Birthdate}} => 42
+	list         {{list .Names}} => Ann, Bo, and Cy; {{list .Names "or" 2}} => Ann, Bo, or 1 other
+	conjoin      {{conjoin .Names "and"}}, as Conjoin
+	pluralize    {{pluralize "seminar" .N}} => seminars
+	count        {{count .N "seminar"}} => 3 seminars
+	countList    {{countList "speaker" .Names}} => 3 speakers: Ann, Bo, and Cy
+	truncate     {{truncate .Title 40}} => A long title…; {{truncate .Title 40 "..."}}
+	wrap         {{wrap .Abstract 72}}
+	slug         {{slug .Title}} => a-long-title
+	transliterate {{transliterate "Straße"}} => Strasse
+	name         {{name .Speaker}} => Dr. Jane Q. Doe; {{name .Speaker "{Last}, {First}"}}
+	nameCase     {{nameCase "MARY O'NEIL"}} => Mary O'Neil
+	text         {{text .HTML}}, as HTMLToText
+	sanitize     {{sanitize .Abstract}}, as SanitizeHTML and safe to insert
+*/
+func FuncMap(opts FuncMapOptions) template.FuncMap {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	return template.FuncMap{
+		"number": func(v any, format ...string) (string, error) {
+			n, err := templateFloat(v)
+			if err != nil {
+				return "", err
+			}
+			return RenderFloat(templateOptional(format, "#,###.##"), n), nil
+		},
+		"integer": func(v any, format ...string) (string, error) {
+			n, err := templateInt(v)
+			if err != nil {
+				return "", err
+			}
+			return RenderInteger(templateOptional(format, "#,###."), int(n)), nil
+		},
+		"amPm": func(v any) (string, error) {
+			hour, err := templateInt(v)
+			if err != nil {
+				return "", err
+			}
+			return Int64ToAmPm(hour), nil
+		},
+		"academicYear": func(v any) (string, error) {
+			year, err := templateInt(v)
+			if err != nil {
+				return "", err
+			}
+			return AcademicYearView(year), nil
+		},
+		"clock": func(v any) (string, error) {
+			t, err := templateTime(v, loc)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return EmojiClockFace(t), nil
+		},
+		"ical": func(v any) (string, error) {
+			t, err := templateTime(v, loc)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return To8601Format(t), nil
+		},
+		"date": func(v any, layout ...string) (string, error) {
+			t, err := templateTime(v, loc)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return t.Format(templateOptional(layout, "January 2, 2006")), nil
+		},
+		"isoWeek": func(v any) (string, error) {
+			t, err := templateTime(v, loc)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return FormatISOWeek(t), nil
+		},
+		"age": func(v any) (int, error) {
+			t, err := templateTime(v, loc)
+			if err != nil || t.IsZero() {
+				return 0, err
+			}
+			return Age(t, now().In(loc)), nil
+		},
+		"list": func(items any, args ...any) (string, error) {
+			names := templateStrings(items)
+			f := ListFormatter{Locale: opts.Locale}
+			if len(args) > 0 {
+				f.Conjunction = fmt.Sprint(args[0])
+			}
+			if len(args) > 1 {
+				max, err := templateInt(args[1])
+				if err != nil {
+					return "", err
+				}
+				f.MaxItems = int(max)
+			}
+			return f.Format(names), nil
+		},
+		"conjoin": func(items any, conjunction ...string) (string, error) {
+			names := templateStrings(items)
+			return Conjoin(names, templateOptional(conjunction, "and")), nil
+		},
+		"pluralize": func(word string, n ...any) (string, error) {
+			count := int64(2)
+			if len(n) > 0 {
+				var err error
+				if count, err = templateInt(n[0]); err != nil {
+					return "", err
+				}
+			}
+			return Pluralize(word, int(count)), nil
+		},
+		"count": func(n any, noun string) (string, error) {
+			count, err := templateInt(n)
+			if err != nil {
+				return "", err
+			}
+			return CountPhrase(int(count), noun), nil
+		},
+		"countList": func(noun string, items any, conjunction ...string) (string, error) {
+			names := templateStrings(items)
+			return CountList(noun, names, templateOptional(conjunction, "and")), nil
+		},
+		"truncate": func(s string, width any, ellipsis ...string) (string, error) {
+			w, err := templateInt(width)
+			if err != nil {
+				return "", err
+			}
+			return Truncate(s, int(w), templateOptional(ellipsis, "…")), nil
+		},
+		"wrap": func(s string, width any) (string, error) {
+			w, err := templateInt(width)
+			if err != nil {
+				return "", err
+			}
+			return Wrap(s, int(w)), nil
+		},
+		"slug": func(s string, separator ...string) string {
+			return Slugify(s, templateOptional(separator, "-"), 0)
+		},
+		"transliterate": Transliterate,
+		"name": func(v any, pattern ...string) (string, error) {
+			var parts NameParts
+			switch n := v.(type) {
+			case NameParts:
+				parts = n
+			case *NameParts:
+				if n != nil {
+					parts = *n
+				}
+			case string:
+				parts = ParseName(n)
+			default:
+				return "", fmt.Errorf(`template function name can't use %T`, v)
+			}
+			return parts.Format(templateOptional(pattern, "{Full}")), nil
+		},
+		"nameCase": NameCase,
+		"text":     HTMLToText,
+		"sanitize": func(s string) template.HTML {
+			return template.HTML(SanitizeHTML(s))
+		},
+	}
+}
+
+// templateOptional returns the first of *args*, or *fallback* without one
+func templateOptional(args []string, fallback string) string {
+	if len(args) > 0 && args[0] != "" {
+		return args[0]
+	}
+	return fallback
+}
+
+// templateFloat reads a number from any numeric type or string
+func templateFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case decimal.Decimal:
+		return n.InexactFloat64(), nil
+	case json.Number:
+		return n.Float64()
+	case string:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(n), ",", ""), 64)
+		if err != nil {
+			return 0, fmt.Errorf(`template argument "%s" is not a number`, n)
+		}
+		return f, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return 0, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return templateFloat(rv.String())
+	}
+	return 0, fmt.Errorf(`template argument of type %T is not a number`, v)
+}
+
+// templateInt reads a whole number, rounding floats toward zero
+func templateInt(v any) (int64, error) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case string:
+		if i, err := strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(n), ",", ""), 10, 64); err == nil {
+			return i, nil
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+	}
+
+	f, err := templateFloat(v)
+	return int64(f), err
+}
+
+// templateTime reads a time from a time.Time, Date, Unix seconds or a
+// date string, in *loc*. Nil, a zero Date and "" give the zero time.
+func templateTime(v any, loc *time.Location) (time.Time, error) {
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return t.In(loc), nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, nil
+		}
+		return t.In(loc), nil
+	case Date:
+		if t.IsZero() {
+			return time.Time{}, nil
+		}
+		return t.In(loc), nil
+	case string:
+		if strings.TrimSpace(t) == "" {
+			return time.Time{}, nil
+		}
+		parsed, err := dateparse.ParseIn(t, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf(`template argument "%s" is not a date`, t)
+		}
+		return parsed.In(loc), nil
+	}
+
+	seconds, err := templateInt(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(`template argument of type %T is not a time`, v)
+	}
+	return time.Unix(seconds, 0).In(loc), nil
+}
+
+// templateStrings reads a list from any slice or array, or a single
+// value as a list of one
+func templateStrings(v any) []string {
+	switch items := v.(type) {
+	case nil:
+		return nil
+	case []string:
+		return items
+	case string:
+		return []string{items}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{fmt.Sprint(v)}
+	}
+
+	result := make([]string, rv.Len())
+	for i := range result {
+		result[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return result
+}
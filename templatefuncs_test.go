@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFuncMap(t *testing.T) {
+	fixed := func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+	funcs := FuncMap(FuncMapOptions{Location: time.UTC, Now: fixed})
+
+	start := time.Date(2020, 1, 2, 14, 0, 0, 0, time.UTC)
+	data := map[string]any{
+		"Start":  start,
+		"PStart": &start,
+		"Date":   NewDate(2020, 1, 2),
+		"Names":  []string{"Ann", "Bo", "Cy"},
+		"Ints":   []int{1, 2},
+		"Int":    13,
+		"Float":  2.5,
+		"Dec":    decimal.RequireFromString("1234.5"),
+		"JSON":   json.Number("7"),
+		"Struct": struct{ N int }{1},
+		"Nil":    nil,
+		"PNil":   (*time.Time)(nil),
+		"Zero":   time.Time{},
+		"NoDate": Date{},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{{number 12345.678}}`, "12,345.68"},
+		{`{{number .Float}}`, "2.50"},
+		{`{{number .Dec "#,###."}}`, "1,235"},
+		{`{{number "1,234.5"}}`, "1,234.50"},
+		{`{{integer 12345}}`, "12,345"},
+		{`{{integer .JSON}}`, "7"},
+		{`{{integer "12345" "#,###."}}`, "12,345"},
+		{`{{amPm .Int}}`, "1p"},
+		{`{{amPm "9"}}`, "9a"},
+		{`{{academicYear 2020}}`, "2019-2020"},
+		{`{{academicYear .JSON}}`, "6-7"},
+		{`{{clock .Start}}`, "🕑"},
+		{`{{clock .PStart}}`, "🕑"},
+		{`{{ical .Start}}`, "20200102T140000Z"},
+		{`{{date .Start}}`, "January 2, 2020"},
+		{`{{date .Date "Jan 2"}}`, "Jan 2"},
+		{`{{date "2020-01-02 10:00" "15:04"}}`, "10:00"},
+		{`{{date 1577973600}}`, "January 2, 2020"},
+		{`{{isoWeek .Start}}`, "2020-W01"},
+		{`{{age "1984-03-05"}}`, "42"},
+		// missing times show as nothing rather than year 1
+		{`{{clock .Nil}}|{{clock .PNil}}|{{clock ""}}|{{clock .Zero}}`, "|||"},
+		{`{{ical .Nil}}|{{ical .PNil}}|{{ical ""}}|{{ical .NoDate}}`, "|||"},
+		{`{{date .Nil}}|{{date .PNil}}|{{date ""}}|{{date .NoDate}}`, "|||"},
+		{`{{isoWeek .Nil}}|{{isoWeek .PNil}}|{{isoWeek ""}}|{{isoWeek .Zero}}`, "|||"},
+		{`{{age .Nil}}|{{age .PNil}}|{{age ""}}|{{age .NoDate}}`, "0|0|0|0"},
+		{`{{list .Names}}`, "Ann, Bo, and Cy"},
+		{`{{list .Names "or"}}`, "Ann, Bo, or Cy"},
+		{`{{list .Names "and" 2}}`, "Ann, Bo, and 1 other"},
+		{`{{list .Ints}}`, "1 and 2"},
+		{`{{conjoin .Names}}`, "Ann, Bo, and Cy"},
+		{`{{conjoin .Names "or"}}`, "Ann, Bo, or Cy"},
+		{`{{pluralize "person"}}`, "people"},
+		{`{{pluralize "seminar" 1}}`, "seminar"},
+		{`{{pluralize "seminar" .Float}}`, "seminars"},
+		{`{{count 0 "seminar"}}`, "no seminars"},
+		{`{{count "1250" "person"}}`, "1,250 people"},
+		{`{{countList "speaker" .Names}}`, "3 speakers: Ann, Bo, and Cy"},
+		{`{{countList "speaker" .Names "or"}}`, "3 speakers: Ann, Bo, or Cy"},
+		{`{{truncate "A very long seminar title" 12}}`, "A very long…"},
+		{`{{truncate "A very long seminar title" .JSON "..."}}`, "A ve..."},
+		{`{{truncate "A very long seminar title" .Float "..."}}`, "A"},
+		{`{{wrap "one two three four" 9}}`, "one two\nthree\nfour"},
+		{`{{slug "Straße Café"}}`, "strasse-cafe"},
+		{`{{slug "a b" "_"}}`, "a_b"},
+		{`{{transliterate "Øresund"}}`, "Oresund"},
+		{`{{name "dr. jane q. doe"}}`, "Dr. Jane Q. Doe"},
+		{`{{name "Jane Q. Doe" "{Last}, {First}"}}`, "Doe, Jane"},
+		{`{{nameCase "MARY SMITH"}}`, "Mary Smith"},
+		{`{{text "<p>Hi <b>there</b></p>"}}`, "Hi there"},
+		{`{{sanitize "<p onclick=x>Tom &amp; Jerry<script>x</script></p>"}}`, "<p>Tom &amp; Jerry</p>"},
+	}
+
+	used := make(map[string]bool)
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("").Funcs(funcs).Parse(tt.template))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			t.Errorf("%s: %v", tt.template, err)
+			continue
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.template, got, tt.want)
+		}
+		used[strings.Fields(strings.Trim(tt.template, "{}"))[0]] = true
+	}
+
+	for key := range funcs {
+		if !used[key] {
+			t.Errorf("FuncMap key %q is not tested", key)
+		}
+	}
+}
+
+func TestFuncMapErrors(t *testing.T) {
+	funcs := FuncMap(FuncMapOptions{Location: time.UTC})
+	data := map[string]any{"Struct": struct{ N int }{1}}
+
+	for _, text := range []string{
+		`{{number .Struct}}`,
+		`{{number "abc"}}`,
+		`{{integer .Struct}}`,
+		`{{date true}}`,
+		`{{date "not a date"}}`,
+		`{{list .Struct "and" "many"}}`,
+		`{{truncate "abc" "wide"}}`,
+		`{{name 5}}`,
+	} {
+		tmpl := template.Must(template.New("").Funcs(funcs).Parse(text))
+		if err := tmpl.Execute(&strings.Builder{}, data); err == nil {
+			t.Errorf("%s: no error", text)
+		}
+	}
+}
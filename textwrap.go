@@ -0,0 +1,304 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// WrapOptions configures WrapWith
+type WrapOptions struct {
+	// BreakLongWords splits words wider than the line; otherwise they
+	// overflow it, which keeps URLs whole
+	BreakLongWords bool
+}
+
+// Wrap breaks *text* into lines of at most *lineWidth* display columns;
+// see WrapWith. Words wider than a line are left whole.
+func Wrap(text string, lineWidth int) string {
+	return WrapWith(text, lineWidth, WrapOptions{})
+}
+
+/*
+WrapWith breaks *text* into lines of at most *lineWidth* display columns
+(see DisplayWidth) at spaces, and between East Asian wide characters,
+which need no spaces. Each line of text is wrapped on its own, so blank
+lines between paragraphs stay, lines that already fit are untouched, and
+a line's indentation is repeated on the lines it wraps onto. Spaces at
+a break are dropped and runs of spaces between words become one.
+*/
+func WrapWith(text string, lineWidth int, opts WrapOptions) string {
+	lines := strings.Split(text, "\n")
+	var out []string
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if lineWidth <= 0 || DisplayWidth(line) <= lineWidth {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, wrapLine(line, lineWidth, opts)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapPiece is a unit that can start a line: a word, or a wide character
+type wrapPiece struct {
+	text  string
+	width int
+	space bool // spaces came before it
+}
+
+// wrapLine wraps one line that is too wide
+func wrapLine(line string, lineWidth int, opts WrapOptions) []string {
+	body := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(body)]
+	indentWidth := tabbedWidth(indent)
+	if indentWidth >= lineWidth {
+		indent, indentWidth = "", 0
+	}
+
+	var lines []string
+	var current strings.Builder
+	used := 0
+	newLine := func() {
+		if used > 0 {
+			lines = append(lines, indent+current.String())
+		}
+		current.Reset()
+		used = 0
+	}
+
+	for _, piece := range wrapPieces(body) {
+		room := lineWidth - indentWidth
+		if used > 0 && piece.space {
+			if used+1+piece.width <= room {
+				current.WriteByte(' ')
+				current.WriteString(piece.text)
+				used += 1 + piece.width
+				continue
+			}
+		} else if used+piece.width <= room {
+			current.WriteString(piece.text)
+			used += piece.width
+			continue
+		}
+
+		newLine()
+		if piece.width <= room || !opts.BreakLongWords {
+			current.WriteString(piece.text)
+			used = piece.width
+			continue
+		}
+
+		// Hard-break a word wider than a line, between graphemes
+		for _, g := range graphemes(piece.text) {
+			w := clusterWidth(g)
+			if used > 0 && used+w > room {
+				newLine()
+			}
+			current.WriteString(g)
+			used += w
+		}
+	}
+	newLine()
+	return lines
+}
+
+// wrapPieces splits text at spaces, and around each wide character
+func wrapPieces(text string) []wrapPiece {
+	var pieces []wrapPiece
+	for i, word := range strings.Fields(text) {
+		piece := wrapPiece{space: i > 0}
+		for _, g := range graphemes(word) {
+			w := clusterWidth(g)
+			if w < 2 {
+				piece.text += g
+				piece.width += w
+				continue
+			}
+			if piece.text != "" {
+				pieces = append(pieces, piece)
+			}
+			pieces = append(pieces, wrapPiece{text: g, width: w, space: piece.space && piece.text == ""})
+			piece = wrapPiece{}
+		}
+		if piece.text != "" {
+			pieces = append(pieces, piece)
+		}
+	}
+	return pieces
+}
+
+// tabbedWidth is the display width of indentation, with tab stops every
+// eight columns
+func tabbedWidth(indent string) int {
+	columns := 0
+	for _, r := range indent {
+		if r == '\t' {
+			columns += 8 - columns%8
+		} else {
+			columns += runeWidth(r)
+		}
+	}
+	return columns
+}
+
+/*
+Truncate shortens *text* to at most *maxWidth* display columns, ending it
+with *ellipsis* (such as "…" or "...") if anything was cut. It never
+splits a grapheme cluster, so accents, emoji sequences and flags stay
+whole, and it drops spaces left before the ellipsis. If the ellipsis
+alone is too wide, the text is cut without one.
+*/
+func Truncate(text string, maxWidth int, ellipsis string) string {
+	if DisplayWidth(text) <= maxWidth {
+		return text
+	}
+
+	room := maxWidth - DisplayWidth(ellipsis)
+	if room < 0 {
+		room, ellipsis = maxWidth, ""
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, g := range graphemes(text) {
+		w := clusterWidth(g)
+		if used+w > room {
+			break
+		}
+		b.WriteString(g)
+		used += w
+	}
+	return strings.TrimRight(b.String(), " ") + ellipsis
+}
+
+// DisplayWidth returns the number of terminal columns *s* takes: East
+// Asian wide and fullwidth characters and emoji count 2, combining marks
+// and other zero-width characters 0, and everything else 1
+func DisplayWidth(s string) int {
+	columns := 0
+	for _, g := range graphemes(s) {
+		columns += clusterWidth(g)
+	}
+	return columns
+}
+
+// clusterWidth is the width of a grapheme cluster: that of its first
+// rune, or 2 for a flag or if it asks for emoji presentation
+func clusterWidth(g string) int {
+	first, _ := utf8.DecodeRuneInString(g)
+	if isRegionalIndicator(first) {
+		return 2
+	}
+	w := runeWidth(first)
+	if w == 1 && strings.ContainsRune(g, '\ufe0f') {
+		return 2
+	}
+	return w
+}
+
+// runeWidth is the display width of a single rune
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || r == '\u200b' || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc):
+		return 0
+	case isHangulJamo(r) && r >= 0x1160:
+		// Medial vowels and final consonants join the syllable before
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+/*
+graphemes splits *s* into user-perceived characters, following the
+Unicode extended grapheme cluster rules closely enough for display: a
+cluster is a rune with any marks, joiners, variation selectors and emoji
+modifiers after it, a ZWJ emoji sequence, a pair of regional indicators
+(a flag), a Hangul syllable spelled in jamo, or CR LF.
+*/
+func graphemes(s string) []string {
+	var clusters []string
+	start := 0
+	var prev rune = -1
+	regional := 0 // regional indicators in a row before this rune
+
+	for i, r := range s {
+		if prev >= 0 && !joinsCluster(prev, r, regional) {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+
+		if isRegionalIndicator(r) {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+// joinsCluster reports whether r continues the cluster that *prev* ends
+func joinsCluster(prev, r rune, regional int) bool {
+	switch {
+	case prev == '\r':
+		return r == '\n'
+	case prev == '\n' || r == '\r' || r == '\n' || unicode.Is(unicode.Cc, prev):
+		return false
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r == '\u200d' || isVariationSelector(r) || isEmojiModifier(r) || (r >= 0xE0020 && r <= 0xE007F):
+		return true
+	case prev == '\u200d':
+		return true
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return regional%2 == 1
+	case isHangulJamo(prev) && isHangulJamo(r):
+		return hangulJoins(prev, r)
+	}
+	return false
+}
+
+func isVariationSelector(r rune) bool {
+	return (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF)
+}
+
+func isEmojiModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isHangulJamo(r rune) bool {
+	return r >= 0x1100 && r <= 0x11FF
+}
+
+// hangulJoins reports whether conjoining jamo continue a syllable:
+// leading consonants before more of them or a vowel, vowels before
+// vowels or trailing consonants, trailing consonants before more
+func hangulJoins(prev, r rune) bool {
+	leading := func(c rune) bool { return c < 0x1160 }
+	vowel := func(c rune) bool { return c >= 0x1160 && c < 0x11A8 }
+	trailing := func(c rune) bool { return c >= 0x11A8 }
+
+	switch {
+	case leading(prev):
+		return leading(r) || vowel(r)
+	case vowel(prev):
+		return vowel(r) || trailing(r)
+	}
+	return trailing(r)
+}
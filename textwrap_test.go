@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"", 10, ""},
+		{"keep", 0, "keep"},
+		{"one two three four", 9, "one two\nthree\nfour"},
+		{"a   b    c", 3, "a b\nc"},
+		{"para one\n\npara two words", 8, "para one\n\npara two\nwords"},
+		{"  indented text that wraps", 12, "  indented\n  text that\n  wraps"},
+		// words wider than the line overflow it
+		{"a https://example.com/very/long b", 10, "a\nhttps://example.com/very/long\nb"},
+		// widths are display columns, not bytes
+		{"héllo wörld", 5, "héllo\nwörld"},
+		{"日本語のテキストです", 6, "日本語\nのテキ\nストで\nす"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.in, tt.width); got != tt.want {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrapBreakLongWords(t *testing.T) {
+	opts := WrapOptions{BreakLongWords: true}
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"abcdefghij xy", 4, "abcd\nefgh\nij\nxy"},
+		{"日本語日本語", 4, "日本\n語日\n本語"},
+	}
+	for _, tt := range tests {
+		if got := WrapWith(tt.in, tt.width, opts); got != tt.want {
+			t.Errorf("WrapWith(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		width    int
+		ellipsis string
+		want     string
+	}{
+		{"short", 10, "…", "short"},
+		{"abc", 3, "...", "abc"},
+		{"A very long seminar title", 12, "…", "A very long…"},
+		{"naïve café", 6, "…", "naïve…"},
+		{"ééé", 2, "", "éé"},
+		{"日本語テキスト", 5, "…", "日本…"},
+		{"👍🏽👍🏽👍🏽", 5, "…", "👍🏽👍🏽…"},
+		{"🇺🇸🇨🇦🇲🇽", 5, "", "🇺🇸🇨🇦"},
+		// an ellipsis as wide as the limit leaves no text
+		{"abcd", 3, "...", "..."},
+		// one wider than the limit is dropped
+		{"abcdef", 2, "...", "ab"},
+		{"abcdef", 0, "…", ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.width, tt.ellipsis); got != tt.want {
+			t.Errorf("Truncate(%q, %d, %q) = %q, want %q", tt.in, tt.width, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"é", 1},
+		{"日本", 4},
+		{"ｆｕｌｌ", 8},
+		{"👍🏽", 2},
+		{"🇺🇸", 2},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.in); got != tt.want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSubN(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want []string
+	}{
+		{"", 3, []string{}},
+		{"abcdefg", 3, []string{"abc", "def", "g"}},
+		{"ab", 5, []string{"ab"}},
+		{"héllo", 2, []string{"hé", "ll", "o"}},
+		{"日本語", 1, []string{"日", "本", "語"}},
+		// no positive length means one piece, not a panic
+		{"abc", 0, []string{"abc"}},
+		{"abc", -2, []string{"abc"}},
+		{"", 0, []string{}},
+	}
+	for _, tt := range tests {
+		if got := SplitSubN(tt.in, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitSubN(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
@@ -375,28 +375,7 @@ func To8601Format(val time.Time) string {
 
 // FirstDayOfISOWeek returns time.Time when fed a year and week
 func FirstDayOfISOWeek(year int, week int, timezone *time.Location) time.Time {
-	date := time.Date(year, 0, 0, 0, 0, 0, 0, timezone)
-	isoYear, isoWeek := date.ISOWeek()
-
-	// iterate back to Monday -- ISO weeks begin on Monday by definition
-	for date.Weekday() != time.Monday {
-		date = date.AddDate(0, 0, -1)
-		isoYear, isoWeek = date.ISOWeek()
-	}
-
-	// iterate forward to the first day of the first week
-	for isoYear < year {
-		date = date.AddDate(0, 0, 7)
-		isoYear, isoWeek = date.ISOWeek()
-	}
-
-	// iterate forward to the first day of the given week
-	for isoWeek < week {
-		date = date.AddDate(0, 0, 7)
-		_, isoWeek = date.ISOWeek()
-	}
-
-	return date
+	return ISOWeek{Year: year, Week: week}.Start(timezone)
 }
 
 // MakeTimeFromTimeField takes the value from
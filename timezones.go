@@ -0,0 +1,330 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+// Zone describes an IANA time zone for display, e.g. in a select box
+type Zone struct {
+	Name         string `json:"name"`         // IANA name, e.g. America/New_York
+	Label        string `json:"label"`        // friendly name, e.g. Eastern Time (US & Canada)
+	Region       string `json:"region"`       // first part of the IANA name, e.g. America
+	Abbreviation string `json:"abbreviation"` // current abbreviation, e.g. EDT
+	Offset       int    `json:"offset"`       // current offset from UTC in seconds
+}
+
+// ZoneGroup is a region's zones, e.g. for an <optgroup>
+type ZoneGroup struct {
+	Region string `json:"region"`
+	Zones  []Zone `json:"zones"`
+}
+
+// zoneCatalog lists commonly chosen zones and their friendly names
+var zoneCatalog = []struct {
+	name  string
+	label string
+}{
+	{"Pacific/Honolulu", "Hawaii"},
+	{"America/Anchorage", "Alaska"},
+	{"America/Los_Angeles", "Pacific Time (US & Canada)"},
+	{"America/Tijuana", "Tijuana"},
+	{"America/Phoenix", "Arizona"},
+	{"America/Denver", "Mountain Time (US & Canada)"},
+	{"America/Chicago", "Central Time (US & Canada)"},
+	{"America/Mexico_City", "Mexico City"},
+	{"America/Regina", "Saskatchewan"},
+	{"America/New_York", "Eastern Time (US & Canada)"},
+	{"America/Toronto", "Toronto"},
+	{"America/Bogota", "Bogota, Lima"},
+	{"America/Halifax", "Atlantic Time (Canada)"},
+	{"America/Puerto_Rico", "Puerto Rico"},
+	{"America/St_Johns", "Newfoundland"},
+	{"America/Sao_Paulo", "Brasilia"},
+	{"America/Argentina/Buenos_Aires", "Buenos Aires"},
+	{"America/Santiago", "Santiago"},
+	{"Atlantic/Azores", "Azores"},
+	{"Atlantic/Reykjavik", "Reykjavik"},
+	{"Etc/UTC", "Coordinated Universal Time"},
+	{"Europe/London", "London, Edinburgh"},
+	{"Europe/Dublin", "Dublin"},
+	{"Europe/Lisbon", "Lisbon"},
+	{"Europe/Paris", "Paris, Brussels"},
+	{"Europe/Berlin", "Berlin, Vienna, Zurich"},
+	{"Europe/Amsterdam", "Amsterdam"},
+	{"Europe/Madrid", "Madrid"},
+	{"Europe/Rome", "Rome"},
+	{"Europe/Stockholm", "Stockholm, Oslo, Copenhagen"},
+	{"Europe/Warsaw", "Warsaw, Prague, Budapest"},
+	{"Europe/Athens", "Athens, Bucharest"},
+	{"Europe/Helsinki", "Helsinki, Kyiv, Riga"},
+	{"Europe/Istanbul", "Istanbul"},
+	{"Europe/Moscow", "Moscow"},
+	{"Africa/Lagos", "West Central Africa"},
+	{"Africa/Cairo", "Cairo"},
+	{"Africa/Johannesburg", "Johannesburg"},
+	{"Africa/Nairobi", "Nairobi"},
+	{"Asia/Jerusalem", "Jerusalem"},
+	{"Asia/Riyadh", "Riyadh"},
+	{"Asia/Tehran", "Tehran"},
+	{"Asia/Dubai", "Abu Dhabi, Dubai"},
+	{"Asia/Karachi", "Karachi"},
+	{"Asia/Kolkata", "Mumbai, New Delhi, Kolkata"},
+	{"Asia/Kathmandu", "Kathmandu"},
+	{"Asia/Dhaka", "Dhaka"},
+	{"Asia/Bangkok", "Bangkok, Hanoi, Jakarta"},
+	{"Asia/Singapore", "Singapore, Kuala Lumpur"},
+	{"Asia/Shanghai", "Beijing, Shanghai"},
+	{"Asia/Hong_Kong", "Hong Kong"},
+	{"Asia/Taipei", "Taipei"},
+	{"Asia/Seoul", "Seoul"},
+	{"Asia/Tokyo", "Tokyo, Osaka"},
+	{"Australia/Perth", "Perth"},
+	{"Australia/Adelaide", "Adelaide"},
+	{"Australia/Darwin", "Darwin"},
+	{"Australia/Brisbane", "Brisbane"},
+	{"Australia/Sydney", "Sydney, Melbourne, Canberra"},
+	{"Pacific/Guam", "Guam"},
+	{"Pacific/Auckland", "Auckland, Wellington"},
+}
+
+// abbreviationZone is a zone that uses an abbreviation, and the fixed
+// UTC offset in seconds the abbreviation stands for in that zone
+type abbreviationZone struct {
+	zone   string
+	offset int
+}
+
+// zoneAbbreviations maps commonly pasted abbreviations to candidate zones.
+// Ambiguous abbreviations (CST, IST, BST) list every plausible zone, and
+// their offsets can differ by zone.
+var zoneAbbreviations = map[string][]abbreviationZone{
+	"UTC":  {{"Etc/UTC", 0}},
+	"GMT":  {{"Europe/London", 0}, {"Etc/UTC", 0}, {"Atlantic/Reykjavik", 0}},
+	"Z":    {{"Etc/UTC", 0}},
+	"HST":  {{"Pacific/Honolulu", -10 * 3600}},
+	"AKST": {{"America/Anchorage", -9 * 3600}},
+	"AKDT": {{"America/Anchorage", -8 * 3600}},
+	"PST":  {{"America/Los_Angeles", -8 * 3600}, {"America/Tijuana", -8 * 3600}},
+	"PDT":  {{"America/Los_Angeles", -7 * 3600}, {"America/Tijuana", -7 * 3600}},
+	"MST":  {{"America/Denver", -7 * 3600}, {"America/Phoenix", -7 * 3600}},
+	"MDT":  {{"America/Denver", -6 * 3600}},
+	"CST":  {{"America/Chicago", -6 * 3600}, {"America/Mexico_City", -6 * 3600}, {"America/Regina", -6 * 3600}, {"Asia/Shanghai", 8 * 3600}, {"Asia/Taipei", 8 * 3600}},
+	"CDT":  {{"America/Chicago", -5 * 3600}},
+	"EST":  {{"America/New_York", -5 * 3600}, {"America/Toronto", -5 * 3600}},
+	"EDT":  {{"America/New_York", -4 * 3600}, {"America/Toronto", -4 * 3600}},
+	"AST":  {{"America/Halifax", -4 * 3600}, {"America/Puerto_Rico", -4 * 3600}, {"Asia/Riyadh", 3 * 3600}},
+	"ADT":  {{"America/Halifax", -3 * 3600}},
+	"NST":  {{"America/St_Johns", -(3*3600 + 1800)}},
+	"NDT":  {{"America/St_Johns", -(2*3600 + 1800)}},
+	"BRT":  {{"America/Sao_Paulo", -3 * 3600}},
+	"ART":  {{"America/Argentina/Buenos_Aires", -3 * 3600}},
+	"WET":  {{"Europe/Lisbon", 0}},
+	"WEST": {{"Europe/Lisbon", 3600}},
+	"BST":  {{"Europe/London", 3600}, {"Asia/Dhaka", 6 * 3600}},
+	"IST":  {{"Europe/Dublin", 3600}, {"Asia/Kolkata", 5*3600 + 1800}, {"Asia/Jerusalem", 2 * 3600}},
+	"CET":  {{"Europe/Paris", 3600}, {"Europe/Berlin", 3600}, {"Europe/Amsterdam", 3600}, {"Europe/Madrid", 3600}, {"Europe/Rome", 3600}, {"Europe/Stockholm", 3600}, {"Europe/Warsaw", 3600}, {"Africa/Lagos", 3600}},
+	"CEST": {{"Europe/Paris", 2 * 3600}, {"Europe/Berlin", 2 * 3600}, {"Europe/Amsterdam", 2 * 3600}, {"Europe/Madrid", 2 * 3600}, {"Europe/Rome", 2 * 3600}, {"Europe/Stockholm", 2 * 3600}, {"Europe/Warsaw", 2 * 3600}},
+	"EET":  {{"Europe/Athens", 2 * 3600}, {"Europe/Helsinki", 2 * 3600}, {"Africa/Cairo", 2 * 3600}},
+	"EEST": {{"Europe/Athens", 3 * 3600}, {"Europe/Helsinki", 3 * 3600}},
+	"MSK":  {{"Europe/Moscow", 3 * 3600}},
+	"SAST": {{"Africa/Johannesburg", 2 * 3600}},
+	"EAT":  {{"Africa/Nairobi", 3 * 3600}},
+	"WAT":  {{"Africa/Lagos", 3600}},
+	"GST":  {{"Asia/Dubai", 4 * 3600}},
+	"PKT":  {{"Asia/Karachi", 5 * 3600}},
+	"ICT":  {{"Asia/Bangkok", 7 * 3600}},
+	"SGT":  {{"Asia/Singapore", 8 * 3600}},
+	"HKT":  {{"Asia/Hong_Kong", 8 * 3600}},
+	"AWST": {{"Australia/Perth", 8 * 3600}},
+	"KST":  {{"Asia/Seoul", 9 * 3600}},
+	"JST":  {{"Asia/Tokyo", 9 * 3600}},
+	"ACST": {{"Australia/Adelaide", 9*3600 + 1800}, {"Australia/Darwin", 9*3600 + 1800}},
+	"ACDT": {{"Australia/Adelaide", 10*3600 + 1800}},
+	"AEST": {{"Australia/Sydney", 10 * 3600}, {"Australia/Brisbane", 10 * 3600}},
+	"AEDT": {{"Australia/Sydney", 11 * 3600}},
+	"ChST": {{"Pacific/Guam", 10 * 3600}},
+	"NZST": {{"Pacific/Auckland", 12 * 3600}},
+	"NZDT": {{"Pacific/Auckland", 13 * 3600}},
+}
+
+// ZoneCatalog returns the catalog of selectable zones with their offsets
+// and abbreviations as of *at*, ordered by offset and then label. Zones
+// missing from the system's tz database are left out.
+func ZoneCatalog(at time.Time) []Zone {
+	var zones []Zone
+
+	for _, z := range zoneCatalog {
+		loc, err := time.LoadLocation(z.name)
+		if err != nil {
+			continue
+		}
+
+		abbreviation, offset := at.In(loc).Zone()
+		zones = append(zones, Zone{
+			Name:         z.name,
+			Label:        z.label,
+			Region:       zoneRegion(z.name),
+			Abbreviation: abbreviation,
+			Offset:       offset,
+		})
+	}
+
+	sort.SliceStable(zones, func(i, j int) bool {
+		if zones[i].Offset != zones[j].Offset {
+			return zones[i].Offset < zones[j].Offset
+		}
+		return zones[i].Label < zones[j].Label
+	})
+
+	return zones
+}
+
+// ZoneGroups returns ZoneCatalog grouped by region, with regions in
+// alphabetical order
+func ZoneGroups(at time.Time) []ZoneGroup {
+	var groups []ZoneGroup
+	index := make(map[string]int)
+
+	for _, z := range ZoneCatalog(at) {
+		i, ok := index[z.Region]
+		if !ok {
+			i = len(groups)
+			index[z.Region] = i
+			groups = append(groups, ZoneGroup{Region: z.Region})
+		}
+		groups[i].Zones = append(groups[i].Zones, z)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Region < groups[j].Region
+	})
+
+	return groups
+}
+
+// OffsetLabel returns the zone's offset as, e.g., UTC-05:00
+func (z Zone) OffsetLabel() string {
+	sign := "+"
+	offset := z.Offset
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// Display returns the zone as shown in a select box, e.g.
+// (UTC-05:00) Eastern Time (US & Canada)
+func (z Zone) Display() string {
+	return fmt.Sprintf("(%s) %s", z.OffsetLabel(), z.Label)
+}
+
+// AbbreviationZones returns the IANA names of zones that use *abbr*
+// (e.g. "CST"), with zones in *preferredRegion* (e.g. "America" or
+// "Asia") listed first. It returns nil for unknown abbreviations.
+func AbbreviationZones(abbr string, preferredRegion string) []string {
+	var zones []string
+	for _, z := range lookupAbbreviation(abbr, preferredRegion) {
+		zones = append(zones, z.zone)
+	}
+	return zones
+}
+
+// LookupLocation returns the location for an IANA name ("Europe/Paris")
+// or a common abbreviation ("CET"). An abbreviation names an offset, not
+// a region, so it returns a fixed zone: "EST" is always UTC-5, even in
+// summer, as in ParseTimeAbbrev. Ambiguous abbreviations take the offset
+// of the first zone in *preferredRegion*, if any; use AbbreviationZones
+// for the zones themselves. Unlike GetLocationFromTZ, it reports why a
+// lookup failed.
+func LookupLocation(name string, preferredRegion string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("time zone is blank")
+	}
+
+	if candidates := lookupAbbreviation(name, preferredRegion); len(candidates) > 0 {
+		return time.FixedZone(abbreviationKey(name), candidates[0].offset), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf(`unknown time zone "%s": %w`, name, err)
+	}
+	return loc, nil
+}
+
+// ParseTimeAbbrev parses a pasted timestamp ending in a zone
+// abbreviation, e.g. "2026-03-01 14:00 EST" or "March 1, 2026 2:00pm IST".
+// Ambiguous abbreviations resolve to the first zone in *preferredRegion*.
+// The abbreviation fixes the offset (EST is always UTC-5, even in summer),
+// and the result is returned in the resolved zone.
+func ParseTimeAbbrev(value string, preferredRegion string) (time.Time, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return time.Time{}, fmt.Errorf(`"%s" has no time zone abbreviation`, value)
+	}
+
+	abbr := fields[len(fields)-1]
+	candidates := lookupAbbreviation(abbr, preferredRegion)
+	if len(candidates) == 0 {
+		return time.Time{}, fmt.Errorf(`unknown time zone abbreviation "%s"`, abbr)
+	}
+
+	fixed := time.FixedZone(abbreviationKey(abbr), candidates[0].offset)
+	t, err := dateparse.ParseIn(strings.Join(fields[:len(fields)-1], " "), fixed)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(candidates[0].zone)
+	if err != nil {
+		return t, nil
+	}
+	return t.In(loc), nil
+}
+
+// FormatInZones formats t in each location using *layout*, joined with
+// " / ", e.g. "10:00 EDT / 15:00 BST / 16:00 CEST"
+func FormatInZones(t time.Time, layout string, locs ...*time.Location) string {
+	formatted := make([]string, 0, len(locs))
+	for _, loc := range locs {
+		formatted = append(formatted, t.In(loc).Format(layout))
+	}
+	return strings.Join(formatted, " / ")
+}
+
+// lookupAbbreviation returns the zones using *abbr*, with those in
+// *preferredRegion* first
+func lookupAbbreviation(abbr string, preferredRegion string) []abbreviationZone {
+	zones := append([]abbreviationZone{}, zoneAbbreviations[abbreviationKey(abbr)]...)
+
+	sort.SliceStable(zones, func(i, j int) bool {
+		return strings.EqualFold(zoneRegion(zones[i].zone), preferredRegion) &&
+			!strings.EqualFold(zoneRegion(zones[j].zone), preferredRegion)
+	})
+
+	return zones
+}
+
+// abbreviationKey returns *abbr* spelled as in zoneAbbreviations ("chst"
+// => "ChST"), or upper-cased if it isn't there
+func abbreviationKey(abbr string) string {
+	for key := range zoneAbbreviations {
+		if strings.EqualFold(key, abbr) {
+			return key
+		}
+	}
+	return strings.ToUpper(abbr)
+}
+
+// zoneRegion returns the first part of an IANA name, e.g. America
+func zoneRegion(name string) string {
+	region, _, _ := strings.Cut(name, "/")
+	return region
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupLocationAbbreviationIsFixed(t *testing.T) {
+	summer := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		region string
+		want   int
+	}{
+		{"EST", "", -5 * 3600},
+		{"est", "", -5 * 3600},
+		{"CST", "America", -6 * 3600},
+		{"CST", "Asia", 8 * 3600},
+		{"MST", "", -7 * 3600},
+		{"PST", "", -8 * 3600},
+		{"IST", "Asia", 5*3600 + 1800},
+	}
+	for _, tt := range tests {
+		loc, err := LookupLocation(tt.name, tt.region)
+		if err != nil {
+			t.Errorf("LookupLocation(%q, %q) error: %v", tt.name, tt.region, err)
+			continue
+		}
+		if _, offset := summer.In(loc).Zone(); offset != tt.want {
+			t.Errorf("LookupLocation(%q, %q) offset in July = %d, want %d", tt.name, tt.region, offset, tt.want)
+		}
+	}
+
+	for in, want := range map[string]string{"ChST": "ChST", "chst": "ChST", "est": "EST"} {
+		loc, err := LookupLocation(in, "")
+		if err != nil {
+			t.Errorf("LookupLocation(%q) error: %v", in, err)
+			continue
+		}
+		if name, _ := summer.In(loc).Zone(); name != want {
+			t.Errorf("LookupLocation(%q) zone name = %q, want %q", in, name, want)
+		}
+	}
+
+	if _, err := LookupLocation("Mars/Olympus_Mons", ""); err == nil {
+		t.Error("LookupLocation accepted an unknown zone")
+	}
+	if _, err := LookupLocation(" ", ""); err == nil {
+		t.Error("LookupLocation accepted a blank zone")
+	}
+}
+
+func TestLookupLocationIANA(t *testing.T) {
+	loc, err := LookupLocation("America/New_York", "")
+	if err != nil {
+		t.Skipf("tz database unavailable: %v", err)
+	}
+	summer := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+	if name, _ := summer.In(loc).Zone(); name != "EDT" {
+		t.Errorf("America/New_York in July = %s, want EDT", name)
+	}
+}
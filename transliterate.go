@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// latinLetters spell Latin letters that have no decomposition, so
+// stripping combining marks doesn't reduce them to ASCII. Only lower case
+// is listed here and in the other tables; capitals are added by init.
+var latinLetters = map[rune]string{
+	'æ': "ae", 'œ': "oe", 'ĳ': "ij", 'ø': "o", 'đ': "d", 'ð': "d", 'ɖ': "d", 'ɗ': "d", 'þ': "th",
+	'ł': "l", 'ŀ': "l", 'ƚ': "l", 'ı': "i", 'ɨ': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng", 'ß': "ss",
+	'ſ': "s", 'ƒ': "f", 'ƀ': "b", 'ɓ': "b", 'ƈ': "c", 'ȼ': "c", 'ɠ': "g", 'ǥ': "g", 'ƙ': "k",
+	'ɲ': "n", 'ƞ': "n", 'ɵ': "o", 'ƥ': "p", 'ɋ': "q", 'ɍ': "r", 'ʂ': "s", 'ƭ': "t", 'ʈ': "t",
+	'ʉ': "u", 'ʋ': "v", 'ƴ': "y", 'ɏ': "y", 'ƶ': "z", 'ʐ': "z", 'ʒ': "zh", 'ȝ': "y", 'ə': "e",
+	'ǝ': "e", 'ɛ': "e", 'ɔ': "o", 'ĸ': "q",
+}
+
+// germanLetters spell umlauts the German way, which ReplaceAccents
+// doesn't so names still match their unaccented spelling
+var germanLetters = map[rune]string{
+	'ä': "ae", 'ö': "oe", 'ü': "ue",
+}
+
+// cyrillicLetters spell Russian, Ukrainian and Belarusian letters
+var cyrillicLetters = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh",
+	'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+}
+
+// greekLetters spell Greek letters; accented vowels are found after their
+// accents are removed
+var greekLetters = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// punctuationSymbols spell punctuation and symbols that compatibility
+// decomposition leaves alone ("…" and "™" already become "..." and "TM")
+var punctuationSymbols = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '′': "'", '‹': "'", '›': "'",
+	'“': `"`, '”': `"`, '„': `"`, '‟': `"`, '″': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '−': "-", '⁄': "/",
+	'•': "*", '·': "*", '¡': "!", '¿': "?", '©': "(c)", '®': "(r)", '°': "deg",
+	'×': "x", '÷': "/", '±': "+/-", '€': "EUR", '£': "GBP", '¥': "JPY", '¢': "c",
+	'§': "S", '¶': "P", '\u200b': "", '\ufeff': "",
+}
+
+// accentLetters is ReplaceAccents' table, and transliterations
+// Transliterate's
+var accentLetters, transliterations map[rune]string
+
+func init() {
+	accentLetters = mergeLetters(latinLetters)
+	transliterations = mergeLetters(latinLetters, germanLetters, cyrillicLetters, greekLetters, punctuationSymbols)
+}
+
+// mergeLetters combines tables, adding capitals for their lower-case
+// letters: "ж" => "zh", so "Ж" => "Zh"
+func mergeLetters(tables ...map[rune]string) map[rune]string {
+	merged := make(map[rune]string)
+	for _, table := range tables {
+		for r, latin := range table {
+			merged[r] = latin
+		}
+	}
+	for _, table := range tables {
+		for r, latin := range table {
+			upper := unicode.ToUpper(r)
+			if _, ok := merged[upper]; ok || upper == r {
+				continue
+			}
+			if latin != "" {
+				latin = strings.ToUpper(latin[:1]) + latin[1:]
+			}
+			merged[upper] = latin
+		}
+	}
+	// ẞ is the capital of ß, though ß upper-cases to itself
+	if latin, ok := merged['ß']; ok {
+		merged['ẞ'] = strings.ToUpper(latin[:1]) + latin[1:]
+	}
+	return merged
+}
+
+/*
+Transliterate spells *s* in ASCII as far as it can: accents are removed,
+Latin letters without them spelled out ("Øresund" => "Oresund", "Straße"
+=> "Strasse", "Đorđe" => "Dorde", "Þór" => "Thor", "œuvre" => "oeuvre"),
+German umlauts expanded ("Müller" => "Mueller"), Cyrillic and Greek
+romanized, compatibility forms decomposed ("ﬁ", "½", "™"), and curly
+quotes, dashes and common symbols replaced. A capital spelled with
+several letters is all capitals between other capitals ("ЖУК" => "ZHUK").
+Runes it can't spell, such as Chinese, are kept.
+*/
+func Transliterate(s string) string {
+	result, _, err := transform.String(Transliterator(), s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
+// Transliterator returns a transform.Transformer that does what
+// Transliterate does, for streams: wrap a reader with
+// transform.NewReader(r, utils.Transliterator()). A Transformer holds
+// state, so use each one for a single stream at a time.
+func Transliterator() transform.Transformer {
+	// NFC first, so decomposed input ("u" and a combining diaeresis)
+	// finds the tables' precomposed letters ("ü")
+	return transform.Chain(
+		norm.NFC,
+		&letterTransformer{table: transliterations},
+		norm.NFKD,
+		runes.Remove(runes.In(unicode.Mn)),
+		&letterTransformer{table: transliterations},
+		norm.NFC,
+	)
+}
+
+// accentRemover returns ReplaceAccents' transform
+func accentRemover() transform.Transformer {
+	return transform.Chain(
+		norm.NFC,
+		&letterTransformer{table: accentLetters},
+		norm.NFD,
+		runes.Remove(runes.In(unicode.Mn)),
+		norm.NFC,
+	)
+}
+
+// letterTransformer replaces runes by a table, keeping others and any
+// invalid UTF-8 as they are
+type letterTransformer struct {
+	table     map[rune]string
+	prevUpper bool // the last rune written was a capital
+}
+
+func (t *letterTransformer) Reset() {
+	t.prevUpper = false
+}
+
+func (t *letterTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		if !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+
+		latin, ok := t.table[r]
+		if !ok {
+			latin = string(src[nSrc : nSrc+size])
+		} else if len(latin) > 1 && unicode.IsUpper(r) {
+			// Capitalize it all among capitals, which needs the next rune
+			rest := src[nSrc+size:]
+			if !atEOF && !utf8.FullRune(rest) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			next, _ := utf8.DecodeRune(rest)
+			if unicode.IsUpper(next) || (t.prevUpper && !unicode.IsLetter(next)) {
+				latin = strings.ToUpper(latin)
+			}
+		}
+
+		if nDst+len(latin) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], latin)
+		nSrc += size
+		t.prevUpper = unicode.IsUpper(r)
+	}
+	return nDst, nSrc, nil
+}
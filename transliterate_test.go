@@ -0,0 +1,55 @@
+package utils
+
+import "testing"
+
+func TestReplaceAccents(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		// shorter output must not carry the input's leftover bytes
+		{"José Łódź", "Jose Lodz"},
+		{"éééé", "eeee"},
+		// longer output must not be cut to the input's length
+		{"æææ", "aeaeae"},
+		{"Øre straße", "Ore strasse"},
+		// Latin letters without accents are spelled out, not kept
+		{"ß ẞ", "ss Ss"},
+		{"Ørsted øl", "Orsted ol"},
+		{"Æsir æther", "Aesir aether"},
+		{"Đorđe Þór", "Dorde Thor"},
+		// decomposed input loses its accents as precomposed input does
+		{"Jose\u0301", "Jose"},
+		{"Mu\u0308ller", "Muller"},
+	}
+	for _, tt := range tests {
+		if got := ReplaceAccents(tt.in); got != tt.want {
+			t.Errorf("ReplaceAccents(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"Müller", "Mueller"},
+		// decomposed input spells umlauts as precomposed input does
+		{"Mu\u0308ller", "Mueller"},
+		{"MU\u0308LLER", "MUELLER"},
+		{"Øresund Straße", "Oresund Strasse"},
+		{"ЖУК Жук", "ZHUK Zhuk"},
+		{"ﬁ ½ ™", "fi 1/2 TM"},
+		{"“quoted” — dash", `"quoted" - dash`},
+		{"北京", "北京"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.in); got != tt.want {
+			t.Errorf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}